@@ -0,0 +1,48 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/interimme/celestial/pkg/celestial"
+	"github.com/interimme/celestial/pkg/chart"
+)
+
+// runDaylight renders the bands of daylight and twilight over a year
+func runDaylight(args []string) error {
+	flags := flag.NewFlagSet("daylight", flag.ExitOnError)
+	var (
+		latFlag       = flags.Float64("lat", 0, "latitude of the observer")
+		longFlag      = flags.Float64("long", 0, "longitude of the observer")
+		elevationFlag = flags.Float64("elev", 0, "elevation of the observer")
+		yearFlag      = flags.Int("year", time.Now().Year(), "year of the chart")
+		tzFlag        = flags.String("tz", "Local", "IANA timezone of the observer")
+		formatFlag    = flags.String("format", "terminal", "output format (terminal or svg)")
+		columnsFlag   = flags.Int("columns", 73, "number of columns of the terminal chart")
+		outFlag       = flags.String("o", "", "file to write the chart to (default stdout)")
+	)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	loc, err := time.LoadLocation(*tzFlag)
+	if err != nil {
+		return fmt.Errorf("failed loading timezone: %v", err)
+	}
+
+	observer := celestial.Observer{Latitude: *latFlag, Longitude: *longFlag, Elevation: *elevationFlag}
+	daylight := chart.NewDaylightChart(observer, *yearFlag, loc)
+
+	switch *formatFlag {
+	case "terminal":
+		return writeOutput(*outFlag, func(w io.Writer) error {
+			return daylight.Terminal(w, *columnsFlag)
+		})
+	case "svg":
+		return writeOutput(*outFlag, daylight.SVG)
+	default:
+		return fmt.Errorf("unknown format %q", *formatFlag)
+	}
+}
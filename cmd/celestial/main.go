@@ -20,7 +20,24 @@ var (
 	date    = "undefined"
 )
 
+// subcommands of the CLI, without one the events of a single day are printed
+var commands = map[string]func(args []string) error{
+	"sunpath":    runSunPath,
+	"daylight":   runDaylight,
+	"terminator": runTerminator,
+	"passes":     runPasses,
+}
+
 func main() {
+	if len(os.Args) > 1 {
+		if command, ok := commands[os.Args[1]]; ok {
+			if err := command(os.Args[2:]); err != nil {
+				log.Fatalf("%s: %v\n", os.Args[1], err)
+			}
+			return
+		}
+	}
+
 	var (
 		dateTimeFormat = "Jan _2 15:04"
 		timeFormat     = "15:04"
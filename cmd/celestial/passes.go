@@ -0,0 +1,76 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/interimme/celestial/pkg/celestial"
+	"github.com/interimme/celestial/pkg/satellite"
+)
+
+// runPasses prints the passes of a satellite from a local file of two-line element sets
+func runPasses(args []string) error {
+	flags := flag.NewFlagSet("passes", flag.ExitOnError)
+	var (
+		tleFlag       = flags.String("tle", "", "file with the two-line element sets, e.g. from CelesTrak")
+		satFlag       = flags.String("sat", "", "name or catalogue number of the satellite (default the first in the file)")
+		latFlag       = flags.Float64("lat", 0, "latitude of the observer")
+		longFlag      = flags.Float64("long", 0, "longitude of the observer")
+		elevationFlag = flags.Float64("elev", 0, "elevation of the observer")
+		timeFlag      = flags.String("time", time.Now().Format(time.RFC3339), "start of the prediction")
+		daysFlag      = flags.Int("days", 3, "number of days to predict")
+		minFlag       = flags.Float64("min", 10, "minimum elevation of a pass in degrees")
+	)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *tleFlag == "" {
+		return fmt.Errorf("the -tle file is required")
+	}
+
+	from, err := time.Parse(time.RFC3339, *timeFlag)
+	if err != nil {
+		return fmt.Errorf("failed parsing time: %v", err)
+	}
+
+	tles, err := satellite.LoadTLEFile(*tleFlag)
+	if err != nil {
+		return err
+	}
+	if len(tles) == 0 {
+		return fmt.Errorf("no element sets in %s", *tleFlag)
+	}
+	tle := tles[0]
+	if *satFlag != "" {
+		var ok bool
+		if tle, ok = satellite.FindTLE(tles, *satFlag); !ok {
+			return fmt.Errorf("satellite %q not found in %s", *satFlag, *tleFlag)
+		}
+	}
+
+	sat, err := satellite.New(tle)
+	if err != nil {
+		return err
+	}
+	observer := celestial.Observer{Latitude: *latFlag, Longitude: *longFlag, Elevation: *elevationFlag}
+	passes, err := sat.Passes(observer, from, from.AddDate(0, 0, *daysFlag), *minFlag)
+	if err != nil {
+		return err
+	}
+
+	const timeFormat = "Jan _2 15:04:05"
+	fmt.Printf("Satellite\t%v (%v)\nElements\t%v\n\n", tle.Name, tle.CatalogNumber, tle.Epoch.Format(time.UnixDate))
+	fmt.Printf("%-15s %4s   %-15s %4s %4s   %-15s %4s   %s\n", "AOS", "Az", "Max", "El", "Az", "LOS", "Az", "Visible")
+	for _, pass := range passes {
+		visible := ""
+		if pass.Visible {
+			visible = "yes"
+		}
+		fmt.Printf("%-15s %4.0f   %-15s %4.0f %4.0f   %-15s %4.0f   %s\n",
+			pass.AOS.In(from.Location()).Format(timeFormat), pass.AOSAzimuth,
+			pass.Culmination.In(from.Location()).Format(timeFormat), pass.MaxElevation, pass.MaxAzimuth,
+			pass.LOS.In(from.Location()).Format(timeFormat), pass.LOSAzimuth, visible)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/interimme/celestial/pkg/celestial"
+	"github.com/interimme/celestial/pkg/chart"
+)
+
+// runSunPath renders a sun-path diagram as SVG
+func runSunPath(args []string) error {
+	flags := flag.NewFlagSet("sunpath", flag.ExitOnError)
+	var (
+		latFlag        = flags.Float64("lat", 0, "latitude of the observer")
+		longFlag       = flags.Float64("long", 0, "longitude of the observer")
+		elevationFlag  = flags.Float64("elev", 0, "elevation of the observer")
+		yearFlag       = flags.Int("year", time.Now().Year(), "year of the diagram")
+		tzFlag         = flags.String("tz", "Local", "IANA timezone of the observer, used for the hour lines")
+		projectionFlag = flags.String("projection", "polar", "projection of the diagram (polar or cartesian)")
+		outFlag        = flags.String("o", "", "file to write the SVG to (default stdout)")
+	)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	var projection chart.Projection
+	switch *projectionFlag {
+	case "polar":
+		projection = chart.Polar
+	case "cartesian":
+		projection = chart.Cartesian
+	default:
+		return fmt.Errorf("unknown projection %q", *projectionFlag)
+	}
+
+	loc, err := time.LoadLocation(*tzFlag)
+	if err != nil {
+		return fmt.Errorf("failed loading timezone: %v", err)
+	}
+
+	observer := celestial.Observer{Latitude: *latFlag, Longitude: *longFlag, Elevation: *elevationFlag}
+	diagram := chart.NewSunPathDiagram(observer, *yearFlag, loc)
+
+	return writeOutput(*outFlag, func(w io.Writer) error {
+		return diagram.SVG(w, projection)
+	})
+}
+
+// writeOutput writes to the named file, or to stdout if the name is empty
+func writeOutput(name string, write func(w io.Writer) error) error {
+	if name == "" {
+		return write(os.Stdout)
+	}
+
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
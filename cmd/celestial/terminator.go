@@ -0,0 +1,33 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/interimme/celestial/pkg/celestial"
+)
+
+// runTerminator writes the day/night line and the twilight zones as GeoJSON
+func runTerminator(args []string) error {
+	flags := flag.NewFlagSet("terminator", flag.ExitOnError)
+	var (
+		timeFlag = flags.String("time", time.Now().Format(time.RFC3339), "instant used for the calculation")
+		outFlag  = flags.String("o", "", "file to write the GeoJSON to (default stdout)")
+	)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	t, err := time.Parse(time.RFC3339, *timeFlag)
+	if err != nil {
+		return fmt.Errorf("failed parsing time: %v", err)
+	}
+
+	collection := celestial.Terminator(t)
+	return writeOutput(*outFlag, func(w io.Writer) error {
+		return json.NewEncoder(w).Encode(collection)
+	})
+}
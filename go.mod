@@ -2,4 +2,4 @@ module github.com/interimme/celestial
 
 go 1.22.5
 
-require github.com/logrusorgru/aurora/v3 v3.0.0 // indirect
+require github.com/logrusorgru/aurora/v3 v3.0.0
@@ -0,0 +1,199 @@
+// Package chinese implements the Chinese lunisolar calendar, whose months start on the day
+// of the new moon and are numbered by the major solar terms they contain, both reckoned in
+// China Standard Time.
+package chinese
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/interimme/celestial/pkg/celestial"
+)
+
+// CST is China Standard Time, UTC+8, in which the days of the calendar are reckoned. Before
+// 1929 the almanacs used the local time of Beijing, 14 minutes behind, so a few months of
+// earlier years start a day apart from them.
+var CST = time.FixedZone("CST", 8*60*60)
+
+var ErrInvalid = errors.New("invalid Chinese date")
+
+// Date is a day of the Chinese calendar. The year is the Gregorian year in which it begins,
+// e.g. 2024 for the year of the dragon that started on 10 February 2024.
+type Date struct {
+	Year  int
+	Month int
+	// Leap is true for the leap month that repeats the number of the month before it
+	Leap bool
+	Day  int
+}
+
+func (d Date) String() string {
+	if d.Leap {
+		return fmt.Sprintf("%d leap month %d day %d", d.Year, d.Month, d.Day)
+	}
+	return fmt.Sprintf("%d month %d day %d", d.Year, d.Month, d.Day)
+}
+
+// Month is a month of the Chinese calendar.
+type Month struct {
+	Number int
+	Leap   bool
+	// Start is midnight CST of the first day, the day of the new moon
+	Start time.Time
+	// Days is the length of the month, 29 or 30
+	Days int
+}
+
+// Midnight CST at the start of the day of a time in CST
+func cst_day(t time.Time) time.Time {
+	t = t.In(CST)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, CST)
+}
+
+// Calculate the months of a sui, the year from the month that contains the December
+// solstice of the year before to the month before the one that contains the solstice of
+// the year
+func sui(year int) []Month {
+	solstice := cst_day(celestial.NextSolarTerm(celestial.Dongzhi, time.Date(year-1, 12, 1, 0, 0, 0, 0, CST)))
+	next := cst_day(celestial.NextSolarTerm(celestial.Dongzhi, time.Date(year, 12, 1, 0, 0, 0, 0, CST)))
+
+	// the months start on the days of the new moons, the first is the last one on or before
+	// the day of the solstice
+	var starts []time.Time
+	for _, phase := range celestial.LunarPhases(solstice.AddDate(0, 0, -31), next.AddDate(0, 0, 31)) {
+		if phase.Phase != celestial.NewMoon {
+			continue
+		}
+		start := cst_day(phase.Time)
+		if !start.After(solstice) {
+			starts = starts[:0]
+		}
+		starts = append(starts, start)
+	}
+
+	var majors []time.Time
+	for _, term := range celestial.SolarTerms(solstice, next.AddDate(0, 0, 1)) {
+		if term.Term.Major() {
+			majors = append(majors, cst_day(term.Time))
+		}
+	}
+	hasMajor := func(month Month) bool {
+		end := month.Start.AddDate(0, 0, month.Days)
+		for _, major := range majors {
+			if !major.Before(month.Start) && major.Before(end) {
+				return true
+			}
+		}
+		return false
+	}
+
+	// the sui ends before the month that contains the next solstice
+	var months []Month
+	for i := 0; i+1 < len(starts) && !starts[i+1].After(next); i++ {
+		months = append(months, Month{Start: starts[i], Days: days_between(starts[i], starts[i+1])})
+	}
+
+	// a sui of 13 months has a leap month, the first without a major term
+	number, leap := 11, len(months) == 13
+	for i := range months {
+		switch {
+		case i == 0:
+		case leap && !hasMajor(months[i]):
+			months[i].Leap, leap = true, false
+		default:
+			number = number%12 + 1
+		}
+		months[i].Number = number
+	}
+	return months
+}
+
+// Count the days between two midnights
+func days_between(from, to time.Time) int {
+	return int(to.Sub(from).Hours()/24 + 0.5)
+}
+
+// Find the first month of a year in its sui
+func first_month(months []Month) int {
+	for i, month := range months {
+		if month.Number == 1 && !month.Leap {
+			return i
+		}
+	}
+	return len(months)
+}
+
+// Months calculates the months of a Chinese year, 12 or 13 with a leap month.
+// Returns:
+//
+//	The months from the first month to the twelfth, with midnight CST of their first days.
+func Months(year int) []Month {
+	return year_months(sui(year), sui(year+1))
+}
+
+// Join the months of a year from its sui and the following
+func year_months(this, following []Month) []Month {
+	return append(this[first_month(this):len(this):len(this)], following[:first_month(following)]...)
+}
+
+// NewYear calculates the Chinese New Year, the first day of the first month, which falls
+// between 21 January and 20 February.
+// Returns:
+//
+//	Midnight CST at the start of the day.
+func NewYear(year int) time.Time {
+	months := sui(year)
+	return months[first_month(months)].Start
+}
+
+// FromTime converts a Gregorian date to a date of the Chinese calendar.
+// Args:
+//
+//	t: The date to convert, the day is taken in the time's timezone
+//
+// Returns:
+//
+//	The Chinese date of the day.
+func FromTime(t time.Time) Date {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, CST)
+	year, this := day.Year(), sui(day.Year())
+	var months []Month
+	if day.Before(this[first_month(this)].Start) {
+		year, months = year-1, year_months(sui(year-1), this)
+	} else {
+		months = year_months(this, sui(year+1))
+	}
+	for _, month := range months {
+		if offset := days_between(month.Start, day); offset < month.Days {
+			return Date{Year: year, Month: month.Number, Leap: month.Leap, Day: offset + 1}
+		}
+	}
+	// not reached, the months of a year follow each other without gaps
+	panic(fmt.Sprintf("%v is not in the year %d", day, year))
+}
+
+// ToTime converts a date of the Chinese calendar to a Gregorian date.
+// Args:
+//
+//	d:   The date to convert
+//	loc: The timezone of the result
+//
+// Returns:
+//
+//	Midnight at the start of the Gregorian day in loc, or ErrInvalid if the year has no
+//	such month or the month does not have the day.
+func ToTime(d Date, loc *time.Location) (time.Time, error) {
+	if d.Month < 1 || d.Month > 12 || d.Day < 1 || d.Day > 30 {
+		return time.Time{}, ErrInvalid
+	}
+	for _, month := range Months(d.Year) {
+		if month.Number == d.Month && month.Leap == d.Leap {
+			if d.Day > month.Days {
+				return time.Time{}, ErrInvalid
+			}
+			return time.Date(month.Start.Year(), month.Start.Month(), month.Start.Day()+d.Day-1, 0, 0, 0, 0, loc), nil
+		}
+	}
+	return time.Time{}, ErrInvalid
+}
@@ -0,0 +1,143 @@
+package chinese
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewYear(t *testing.T) {
+	var tests = []time.Time{
+		time.Date(1900, 1, 31, 0, 0, 0, 0, CST),
+		// the New Year came late after the leap 10th month of 1984
+		time.Date(1985, 2, 20, 0, 0, 0, 0, CST),
+		time.Date(2000, 2, 5, 0, 0, 0, 0, CST),
+		time.Date(2020, 1, 25, 0, 0, 0, 0, CST),
+		time.Date(2021, 2, 12, 0, 0, 0, 0, CST),
+		time.Date(2022, 2, 1, 0, 0, 0, 0, CST),
+		time.Date(2023, 1, 22, 0, 0, 0, 0, CST),
+		time.Date(2024, 2, 10, 0, 0, 0, 0, CST),
+		time.Date(2025, 1, 29, 0, 0, 0, 0, CST),
+		time.Date(2026, 2, 17, 0, 0, 0, 0, CST),
+		time.Date(2027, 2, 6, 0, 0, 0, 0, CST),
+		time.Date(2028, 1, 26, 0, 0, 0, 0, CST),
+		time.Date(2029, 2, 13, 0, 0, 0, 0, CST),
+		time.Date(2030, 2, 3, 0, 0, 0, 0, CST),
+		time.Date(2034, 2, 19, 0, 0, 0, 0, CST),
+		time.Date(2100, 2, 9, 0, 0, 0, 0, CST),
+	}
+
+	for _, test := range tests {
+		t.Run(test.Format(time.DateOnly), func(t *testing.T) {
+			if got := NewYear(test.Year()); !got.Equal(test) {
+				t.Errorf("got %v, want %v", got, test)
+			}
+		})
+	}
+}
+
+func TestNewYearRange(t *testing.T) {
+	// the New Year is the second new moon after the December solstice, unless a leap month
+	// comes between, so it falls between 21 January and 20 February
+	next := NewYear(2000)
+	for year := 2000; year < 2100; year++ {
+		start := next
+		next = NewYear(year + 1)
+		if start.Before(time.Date(year, 1, 21, 0, 0, 0, 0, CST)) || start.After(time.Date(year, 2, 20, 0, 0, 0, 0, CST)) {
+			t.Errorf("%d: New Year on %v", year, start)
+		}
+		// a year has 12 or 13 months of 29 or 30 days
+		if days := days_between(start, next); (days < 353 || days > 355) && (days < 383 || days > 385) {
+			t.Errorf("%d: %d days", year, days)
+		}
+	}
+}
+
+func TestMonths(t *testing.T) {
+	var tests = []struct {
+		year  int
+		leap  int
+		start time.Time
+	}{
+		{2020, 4, time.Date(2020, 5, 23, 0, 0, 0, 0, CST)},
+		{2023, 2, time.Date(2023, 3, 22, 0, 0, 0, 0, CST)},
+		{2024, 0, time.Time{}},
+		{2025, 6, time.Date(2025, 7, 25, 0, 0, 0, 0, CST)},
+		// the leap 11th month that followed the solstice, which naive rules put elsewhere
+		{2033, 11, time.Date(2033, 12, 22, 0, 0, 0, 0, CST)},
+	}
+
+	for _, test := range tests {
+		months := Months(test.year)
+		if want := 12 + min(test.leap, 1); len(months) != want {
+			t.Fatalf("%d: got %d months, want %d", test.year, len(months), want)
+		}
+		number := 0
+		for i, month := range months {
+			if month.Leap {
+				if month.Number != test.leap || !month.Start.Equal(test.start) {
+					t.Errorf("%d: got leap month %d on %v", test.year, month.Number, month.Start)
+				}
+			} else if number++; month.Number != number {
+				t.Errorf("%d: got month %d, want %d", test.year, month.Number, number)
+			}
+			if month.Days != 29 && month.Days != 30 {
+				t.Errorf("%d: month %d has %d days", test.year, month.Number, month.Days)
+			}
+			if i > 0 && !months[i-1].Start.AddDate(0, 0, months[i-1].Days).Equal(month.Start) {
+				t.Errorf("%d: month %d does not follow the one before", test.year, month.Number)
+			}
+		}
+		if !months[0].Start.Equal(NewYear(test.year)) || !months[len(months)-1].Start.AddDate(0, 0, months[len(months)-1].Days).Equal(NewYear(test.year+1)) {
+			t.Errorf("%d: months do not fill the year", test.year)
+		}
+	}
+}
+
+func TestFromTimeToTime(t *testing.T) {
+	var tests = []struct {
+		date time.Time
+		want Date
+	}{
+		{time.Date(2024, 2, 10, 0, 0, 0, 0, CST), Date{2024, 1, false, 1}},
+		{time.Date(2024, 2, 9, 0, 0, 0, 0, CST), Date{2023, 12, false, 30}},
+		// the Mid-Autumn Festival of 2024
+		{time.Date(2024, 9, 17, 0, 0, 0, 0, CST), Date{2024, 8, false, 15}},
+		{time.Date(2023, 4, 1, 0, 0, 0, 0, CST), Date{2023, 2, true, 11}},
+		{time.Date(2034, 1, 1, 0, 0, 0, 0, CST), Date{2033, 11, true, 11}},
+		// the date is taken in the timezone of the time
+		{time.Date(2024, 2, 9, 20, 0, 0, 0, time.UTC), Date{2023, 12, false, 30}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.want.String(), func(t *testing.T) {
+			if got := FromTime(test.date); got != test.want {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+			date, err := ToTime(test.want, test.date.Location())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := time.Date(test.date.Year(), test.date.Month(), test.date.Day(), 0, 0, 0, 0, test.date.Location()); !date.Equal(want) {
+				t.Errorf("got %v, want %v", date, want)
+			}
+		})
+	}
+}
+
+func TestToTimeInvalid(t *testing.T) {
+	var tests = []Date{
+		{2024, 0, false, 1},
+		{2024, 13, false, 1},
+		{2024, 1, false, 0},
+		// 2024 has no leap month
+		{2024, 4, true, 1},
+		// the 12th month of 2024 has 29 days
+		{2024, 12, false, 30},
+	}
+
+	for _, test := range tests {
+		if _, err := ToTime(test, CST); err != ErrInvalid {
+			t.Errorf("%v: got %v", test, err)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package chinese
+
+import (
+	"fmt"
+)
+
+// Stem is one of the ten heavenly stems of the sexagenary cycle.
+type Stem int
+
+const (
+	Jia Stem = iota
+	Yi
+	Bing
+	Ding
+	Wu
+	Ji
+	Geng
+	Xin
+	Ren
+	Gui
+)
+
+var stemNames = [...]string{"Jia", "Yi", "Bing", "Ding", "Wu", "Ji", "Geng", "Xin", "Ren", "Gui"}
+
+func (s Stem) String() string {
+	if s < Jia || s > Gui {
+		return fmt.Sprintf("%%!Stem(%d)", int(s))
+	}
+	return stemNames[s]
+}
+
+// Element is the element of the stem, each element has two stems in a row.
+func (s Stem) Element() string {
+	if s < Jia || s > Gui {
+		return fmt.Sprintf("%%!Stem(%d)", int(s))
+	}
+	return [...]string{"Wood", "Fire", "Earth", "Metal", "Water"}[s/2]
+}
+
+// Branch is one of the twelve earthly branches of the sexagenary cycle.
+type Branch int
+
+const (
+	Zi Branch = iota
+	Chou
+	Yin
+	Mao
+	Chen
+	Si
+	// BranchWu is the branch Wu of the horse, apart from the stem Wu
+	BranchWu
+	Wei
+	Shen
+	You
+	Xu
+	Hai
+)
+
+var branchNames = [...]string{"Zi", "Chou", "Yin", "Mao", "Chen", "Si", "Wu", "Wei", "Shen", "You", "Xu", "Hai"}
+
+var animals = [...]string{"Rat", "Ox", "Tiger", "Rabbit", "Dragon", "Snake", "Horse", "Goat", "Monkey", "Rooster", "Dog", "Pig"}
+
+func (b Branch) String() string {
+	if b < Zi || b > Hai {
+		return fmt.Sprintf("%%!Branch(%d)", int(b))
+	}
+	return branchNames[b]
+}
+
+// Animal is the animal of the zodiac for the branch, e.g. Dragon for Chen.
+func (b Branch) Animal() string {
+	if b < Zi || b > Hai {
+		return fmt.Sprintf("%%!Branch(%d)", int(b))
+	}
+	return animals[b]
+}
+
+// StemBranch calculates the stem and branch of a Chinese year in the sexagenary cycle,
+// which started again with Jia-Zi in 1984.
+func StemBranch(year int) (Stem, Branch) {
+	n := ((year-1984)%60 + 60) % 60
+	return Stem(n % 10), Branch(n % 12)
+}
+
+// StemBranch returns the stem and branch of the year of the date, see StemBranch.
+func (d Date) StemBranch() (Stem, Branch) {
+	return StemBranch(d.Year)
+}
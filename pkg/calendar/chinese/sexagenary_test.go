@@ -0,0 +1,44 @@
+package chinese
+
+import (
+	"testing"
+)
+
+func TestStemBranch(t *testing.T) {
+	var tests = []struct {
+		year   int
+		stem   Stem
+		branch Branch
+		animal string
+	}{
+		{1984, Jia, Zi, "Rat"},
+		{2024, Jia, Chen, "Dragon"},
+		{2025, Yi, Si, "Snake"},
+		{2026, Bing, BranchWu, "Horse"},
+		{1900, Geng, Zi, "Rat"},
+		{1911, Xin, Hai, "Pig"},
+		{2100, Geng, Shen, "Monkey"},
+		// 4 AD started a cycle
+		{4, Jia, Zi, "Rat"},
+		{-1, Ji, Wei, "Goat"},
+	}
+
+	for _, test := range tests {
+		stem, branch := StemBranch(test.year)
+		if stem != test.stem || branch != test.branch || branch.Animal() != test.animal {
+			t.Errorf("%d: got %v-%v %s, want %v-%v %s", test.year, stem, branch, branch.Animal(), test.stem, test.branch, test.animal)
+		}
+	}
+	if got := Bing.Element(); got != "Fire" {
+		t.Errorf("got %q", got)
+	}
+	if got := BranchWu.String(); got != "Wu" {
+		t.Errorf("got %q", got)
+	}
+	if got := Stem(10).Element(); got != "%!Stem(10)" {
+		t.Errorf("got %q", got)
+	}
+	if got := Branch(-1).Animal(); got != "%!Branch(-1)" {
+		t.Errorf("got %q", got)
+	}
+}
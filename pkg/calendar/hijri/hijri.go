@@ -0,0 +1,180 @@
+// Package hijri converts between Gregorian dates and dates of the Islamic calendar, by the
+// arithmetic tabular calendar, the Umm al-Qura calendar of Saudi Arabia or months that
+// start with the sighting of the crescent moon.
+package hijri
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrOutOfRange = errors.New("date is outside the range of the calendar")
+	ErrInvalid    = errors.New("invalid Hijri date")
+)
+
+// Month is a month of the Islamic year.
+type Month int
+
+const (
+	Muharram Month = 1 + iota
+	Safar
+	RabiAlAwwal
+	RabiAlThani
+	JumadaAlUla
+	JumadaAlAkhirah
+	Rajab
+	Shaban
+	Ramadan
+	Shawwal
+	DhuAlQadah
+	DhuAlHijjah
+)
+
+var monthNames = [...]string{
+	"Muharram", "Safar", "Rabi' al-Awwal", "Rabi' al-Thani", "Jumada al-Ula", "Jumada al-Akhirah",
+	"Rajab", "Sha'ban", "Ramadan", "Shawwal", "Dhu al-Qa'dah", "Dhu al-Hijjah",
+}
+
+func (m Month) String() string {
+	if m < Muharram || m > DhuAlHijjah {
+		return fmt.Sprintf("%%!Month(%d)", int(m))
+	}
+	return monthNames[m-1]
+}
+
+// Date is a day of the Islamic calendar. Years are counted from the Hijra, the year 1 AH
+// started in July 622.
+type Date struct {
+	Year  int
+	Month Month
+	Day   int
+}
+
+func (d Date) String() string {
+	return fmt.Sprintf("%d %s %d AH", d.Day, d.Month, d.Year)
+}
+
+// Calendar is a variant of the Islamic calendar, which differ in the days on which the
+// months start.
+type Calendar interface {
+	// MonthStart returns the first day of a month as midnight UTC of its Gregorian date
+	MonthStart(year int, month Month) (time.Time, error)
+}
+
+// The month after a month
+func next_month(year int, month Month) (int, Month) {
+	if month == DhuAlHijjah {
+		return year + 1, Muharram
+	}
+	return year, month + 1
+}
+
+// The month before a month
+func previous_month(year int, month Month) (int, Month) {
+	if month == Muharram {
+		return year - 1, DhuAlHijjah
+	}
+	return year, month - 1
+}
+
+// Convert a day number counted from 1 Muharram 1 of the tabular calendar to midnight UTC
+func day_to_time(day int) time.Time {
+	// 1 Muharram 1 is 16 July 622 in the Julian calendar, 19 July 622 in the proleptic
+	// Gregorian calendar of package time
+	return time.Date(622, 7, 19+day, 0, 0, 0, 0, time.UTC)
+}
+
+// Convert the Gregorian date of a time in its own timezone to a day number counted from
+// 1 Muharram 1 of the tabular calendar
+func time_to_day(t time.Time) int {
+	date := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	// a time.Duration only spans 292 years
+	return int((date.Unix() - day_to_time(0).Unix()) / (24 * 60 * 60))
+}
+
+// MonthLength returns the number of days of a month in a calendar, 29 or 30.
+func MonthLength(c Calendar, year int, month Month) (int, error) {
+	start, err := c.MonthStart(year, month)
+	if err != nil {
+		return 0, err
+	}
+	end, err := c.MonthStart(next_month(year, month))
+	if err != nil {
+		return 0, err
+	}
+	return time_to_day(end) - time_to_day(start), nil
+}
+
+// FromTime converts a Gregorian date to a date of the Islamic calendar.
+//
+// The Islamic day begins at sunset, but here the date follows the civil day from midnight
+// to midnight, as printed in calendars.
+// Args:
+//
+//	c: The calendar to convert to
+//	t: The date to convert, the day is taken in the time's timezone
+//
+// Returns:
+//
+//	The Hijri date, or an error if the day is outside the range of the calendar.
+func FromTime(c Calendar, t time.Time) (Date, error) {
+	day := time_to_day(t)
+	if day < 0 {
+		return Date{}, ErrOutOfRange
+	}
+
+	// all variants are within a few days of the tabular calendar, start from its month
+	estimate := Tabular{}.from_day(day)
+	year, month := estimate.Year, estimate.Month
+	for i := 0; i < 3; i++ {
+		start, err := c.MonthStart(year, month)
+		if err != nil {
+			return Date{}, err
+		}
+		if first := time_to_day(start); day < first {
+			year, month = previous_month(year, month)
+			continue
+		}
+		end, err := c.MonthStart(next_month(year, month))
+		if err != nil {
+			return Date{}, err
+		}
+		if last := time_to_day(end); day >= last {
+			year, month = next_month(year, month)
+			continue
+		}
+		return Date{Year: year, Month: month, Day: day - time_to_day(start) + 1}, nil
+	}
+	return Date{}, ErrOutOfRange
+}
+
+// ToTime converts a date of the Islamic calendar to a Gregorian date.
+// Args:
+//
+//	c:   The calendar to convert from
+//	d:   The date to convert
+//	loc: The timezone of the result
+//
+// Returns:
+//
+//	Midnight at the start of the Gregorian day in loc, or ErrInvalid if the month does not
+//	have the day.
+func ToTime(c Calendar, d Date, loc *time.Location) (time.Time, error) {
+	if d.Month < Muharram || d.Month > DhuAlHijjah || d.Day < 1 || d.Day > 30 {
+		return time.Time{}, ErrInvalid
+	}
+	length, err := MonthLength(c, d.Year, d.Month)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if d.Day > length {
+		return time.Time{}, ErrInvalid
+	}
+	start, err := c.MonthStart(d.Year, d.Month)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Date(start.Year(), start.Month(), start.Day()+d.Day-1, 0, 0, 0, 0, loc), nil
+}
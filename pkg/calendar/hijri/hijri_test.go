@@ -0,0 +1,80 @@
+package hijri
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMonthString(t *testing.T) {
+	if got := Ramadan.String(); got != "Ramadan" {
+		t.Errorf("got %q", got)
+	}
+	if got := Month(13).String(); got != "%!Month(13)" {
+		t.Errorf("got %q", got)
+	}
+	if got := (Date{1445, Shawwal, 1}).String(); got != "1 Shawwal 1445 AH" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestFromTimeLocation(t *testing.T) {
+	// the date is taken in the timezone of the time
+	loc := time.FixedZone("EST", -5*3600)
+	got, err := FromTime(Tabular{}, time.Date(2024, 3, 10, 23, 0, 0, 0, loc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (Date{1445, Shaban, 29}); got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	date, err := ToTime(Tabular{}, Date{1445, Ramadan, 1}, loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2024, 3, 11, 0, 0, 0, 0, loc); !date.Equal(want) {
+		t.Errorf("got %v, want %v", date, want)
+	}
+}
+
+func TestToTimeInvalid(t *testing.T) {
+	var tests = []Date{
+		{1445, Month(0), 1},
+		{1445, Month(13), 1},
+		{1445, Ramadan, 0},
+		// Shawwal has 29 days in the tabular calendar
+		{1445, Shawwal, 30},
+	}
+
+	for _, test := range tests {
+		if _, err := ToTime(Tabular{}, test, time.UTC); err != ErrInvalid {
+			t.Errorf("%v: got %v", test, err)
+		}
+	}
+	if _, err := ToTime(Tabular{}, Date{0, Muharram, 1}, time.UTC); err != ErrOutOfRange {
+		t.Errorf("got %v", err)
+	}
+}
+
+func TestMonthLength(t *testing.T) {
+	var tests = []struct {
+		calendar Calendar
+		month    Month
+		want     int
+	}{
+		{Tabular{}, Ramadan, 30},
+		{Tabular{}, DhuAlHijjah, 30},
+		{UmmAlQura{}, Ramadan, 30},
+		{UmmAlQura{}, Shawwal, 29},
+	}
+
+	for _, test := range tests {
+		got, err := MonthLength(test.calendar, 1445, test.month)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != test.want {
+			t.Errorf("%T %v: got %d, want %d", test.calendar, test.month, got, test.want)
+		}
+	}
+}
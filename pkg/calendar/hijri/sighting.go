@@ -0,0 +1,63 @@
+package hijri
+
+import (
+	"time"
+
+	"github.com/interimme/celestial/pkg/celestial"
+)
+
+// Sighting is a calendar whose months start with the predicted sighting of the crescent
+// moon at a place, as the months are begun by the local sighting in many countries. A
+// month starts on the day after the first evening on which a crescent visibility criterion
+// says that the new crescent can be seen, see celestial.Crescent.
+//
+// If the crescent is not visible on the evening of the conjunction or the two evenings
+// after it, which happens at high latitudes, the month starts after the third evening.
+type Sighting struct {
+	Observer celestial.Observer
+	// Location is the timezone of the observer, which decides the days, nil for UTC
+	Location *time.Location
+	// Visible decides whether the crescent can be seen, nil for
+	// celestial.CrescentVisibility.Visible, the naked eye by Yallop and Odeh. E.g. to
+	// accept sightings with optical aid:
+	//
+	//	func(c celestial.CrescentVisibility) bool { return c.Odeh <= celestial.OdehOpticalAid }
+	Visible func(celestial.CrescentVisibility) bool
+}
+
+// MonthStart returns the first day of a month as midnight UTC of its Gregorian date, see
+// Calendar.
+// Returns:
+//
+//	The first day of the month, or an error if the sun does not set for the observer on
+//	one of the evenings.
+func (c Sighting) MonthStart(year int, month Month) (time.Time, error) {
+	tabular, err := Tabular{}.MonthStart(year, month)
+	if err != nil {
+		return time.Time{}, err
+	}
+	loc := c.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	visible := c.Visible
+	if visible == nil {
+		visible = celestial.CrescentVisibility.Visible
+	}
+
+	// the tabular month starts a day or two after the conjunction, give or take two days
+	conjunction := celestial.NextLunarPhase(celestial.NewMoon, tabular.AddDate(0, 0, -16)).In(loc)
+	day := time.Date(conjunction.Year(), conjunction.Month(), conjunction.Day(), 0, 0, 0, 0, loc)
+	start := day.AddDate(0, 0, 3)
+	for i := 0; i < 3; i++ {
+		crescent, err := celestial.Crescent(c.Observer, day.AddDate(0, 0, i))
+		if err != nil {
+			return time.Time{}, err
+		}
+		if visible(crescent) {
+			start = day.AddDate(0, 0, i+1)
+			break
+		}
+	}
+	return time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC), nil
+}
@@ -0,0 +1,76 @@
+package hijri
+
+import (
+	"testing"
+	"time"
+
+	"github.com/interimme/celestial/pkg/celestial"
+)
+
+func TestSighting(t *testing.T) {
+	mecca := Sighting{Observer: celestial.Observer{Latitude: 21.4225, Longitude: 39.8262}, Location: time.FixedZone("AST", 3*3600)}
+	losAngeles := Sighting{
+		Observer: celestial.Observer{Latitude: 34.05, Longitude: -118.25},
+		Location: time.FixedZone("PDT", -7*3600),
+		Visible:  func(c celestial.CrescentVisibility) bool { return c.Yallop <= celestial.YallopC },
+	}
+	var tests = []struct {
+		name     string
+		calendar Sighting
+		month    Month
+		want     time.Time
+	}{
+		// the crescent of Ramadan 1445 could be found with optical aid on 2024-03-10 in the
+		// Americas but not in Arabia
+		{"Mecca", mecca, Ramadan, time.Date(2024, 3, 12, 0, 0, 0, 0, time.UTC)},
+		{"Los Angeles", losAngeles, Ramadan, time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC)},
+		// the crescent of Shawwal 1445 was seen everywhere on 2024-04-09
+		{"Mecca", mecca, Shawwal, time.Date(2024, 4, 10, 0, 0, 0, 0, time.UTC)},
+		{"Los Angeles", losAngeles, Shawwal, time.Date(2024, 4, 10, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name+" "+test.month.String(), func(t *testing.T) {
+			got, err := test.calendar.MonthStart(1445, test.month)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.Equal(test.want) {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestSightingCriterion(t *testing.T) {
+	// with a telescope the crescent is found earlier than by eye
+	observer := celestial.Observer{Latitude: 21.4225, Longitude: 39.8262}
+	nakedEye := Sighting{Observer: observer}
+	telescope := Sighting{Observer: observer, Visible: func(c celestial.CrescentVisibility) bool {
+		return c.Odeh <= celestial.OdehOpticalAid
+	}}
+
+	days := 0
+	for month := Muharram; month <= DhuAlHijjah; month++ {
+		eye, err := nakedEye.MonthStart(1446, month)
+		if err != nil {
+			t.Fatal(err)
+		}
+		aided, err := telescope.MonthStart(1446, month)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if aided.After(eye) {
+			t.Errorf("%v: %v with optical aid after %v", month, aided, eye)
+		}
+		days += int(eye.Sub(aided).Hours() / 24)
+
+		// the months of the year follow each other
+		if date, err := FromTime(nakedEye, eye); err != nil || date != (Date{1446, month, 1}) {
+			t.Errorf("%v: %v is %v, %v", month, eye, date, err)
+		}
+	}
+	if days == 0 {
+		t.Error("optical aid never helps")
+	}
+}
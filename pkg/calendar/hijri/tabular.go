@@ -0,0 +1,93 @@
+package hijri
+
+import (
+	"time"
+)
+
+// LeapYears is a pattern of the 11 leap years in the 30 year cycle of the tabular calendar,
+// in which the last month has 30 days instead of 29.
+type LeapYears int
+
+const (
+	// LeapYears16 has leap years 2, 5, 7, 10, 13, 16, 18, 21, 24, 26 and 29, the most common
+	// pattern
+	LeapYears16 LeapYears = iota
+	// LeapYears15 has 15 instead of 16, the "Kuwaiti algorithm" of Microsoft
+	LeapYears15
+	// Fatimid has leap years 2, 5, 8, 10, 13, 16, 19, 21, 24, 27 and 29, used by the
+	// Fatimids and the Ismaili
+	Fatimid
+	// HabashAlHasib has leap years 2, 5, 8, 11, 13, 16, 19, 21, 24, 27 and 30
+	HabashAlHasib
+)
+
+var leapYears = [...][11]int{
+	LeapYears16:   {2, 5, 7, 10, 13, 16, 18, 21, 24, 26, 29},
+	LeapYears15:   {2, 5, 7, 10, 13, 15, 18, 21, 24, 26, 29},
+	Fatimid:       {2, 5, 8, 10, 13, 16, 19, 21, 24, 27, 29},
+	HabashAlHasib: {2, 5, 8, 11, 13, 16, 19, 21, 24, 27, 30},
+}
+
+// The 30 year cycle has 360 months of 29 and 30 days and 11 leap days
+const cycleDays = 30*354 + 11
+
+// Tabular is the arithmetic Islamic calendar, in which the months alternate between 30 and
+// 29 days and the last month has 30 days in 11 leap years of a 30 year cycle. It starts
+// on 16 July 622 in the Julian calendar, the civil epoch.
+type Tabular struct {
+	Leap LeapYears
+}
+
+// IsLeap tells whether Dhu al-Hijjah of a year has 30 days.
+func (c Tabular) IsLeap(year int) bool {
+	position := (year-1)%30 + 1
+	for _, leap := range leapYears[c.Leap] {
+		if leap == position {
+			return true
+		}
+	}
+	return false
+}
+
+// Count the days from 1 Muharram 1 to the first day of a year
+func (c Tabular) year_start(year int) int {
+	cycles, rest := (year-1)/30, (year-1)%30
+	days := cycles*cycleDays + rest*354
+	for _, leap := range leapYears[c.Leap] {
+		if leap <= rest {
+			days++
+		}
+	}
+	return days
+}
+
+// Count the days from the first day of a year to the first day of a month
+func month_offset(month Month) int {
+	return 29*int(month-1) + int(month)/2
+}
+
+// MonthStart returns the first day of a month as midnight UTC of its Gregorian date, see
+// Calendar.
+func (c Tabular) MonthStart(year int, month Month) (time.Time, error) {
+	if year < 1 {
+		return time.Time{}, ErrOutOfRange
+	}
+	if month < Muharram || month > DhuAlHijjah {
+		return time.Time{}, ErrInvalid
+	}
+	return day_to_time(c.year_start(year) + month_offset(month)), nil
+}
+
+// Convert a day number counted from 1 Muharram 1 to a date
+func (c Tabular) from_day(day int) Date {
+	year := 30*(day/cycleDays) + 1
+	for c.year_start(year+1) <= day {
+		year++
+	}
+	rest := day - c.year_start(year)
+	month := DhuAlHijjah
+	for month_offset(month) > rest {
+		month--
+	}
+	return Date{Year: year, Month: month, Day: rest - month_offset(month) + 1}
+}
@@ -0,0 +1,74 @@
+package hijri
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTabular(t *testing.T) {
+	var tests = []struct {
+		date time.Time
+		want Date
+	}{
+		// the epoch, a Friday
+		{time.Date(622, 7, 19, 0, 0, 0, 0, time.UTC), Date{1, Muharram, 1}},
+		{time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC), Date{1410, JumadaAlAkhirah, 3}},
+		{time.Date(2000, 3, 7, 0, 0, 0, 0, time.UTC), Date{1420, DhuAlHijjah, 1}},
+		{time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC), Date{1445, Ramadan, 1}},
+		// 1445 is a leap year
+		{time.Date(2024, 7, 7, 0, 0, 0, 0, time.UTC), Date{1445, DhuAlHijjah, 30}},
+		{time.Date(2050, 12, 31, 0, 0, 0, 0, time.UTC), Date{1473, RabiAlThani, 16}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.want.String(), func(t *testing.T) {
+			got, err := FromTime(Tabular{}, test.date)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+			date, err := ToTime(Tabular{}, test.want, time.UTC)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !date.Equal(test.date) {
+				t.Errorf("got %v, want %v", date, test.date)
+			}
+		})
+	}
+}
+
+func TestTabularLeapYears(t *testing.T) {
+	var tests = []struct {
+		leap LeapYears
+		year int
+		want bool
+	}{
+		{LeapYears16, 1445, true},
+		{LeapYears16, 1446, false},
+		{LeapYears16, 1456, true},
+		{LeapYears15, 1456, false},
+		{LeapYears15, 1455, true},
+		{Fatimid, 1436, false},
+		{Fatimid, 1437, true},
+		{HabashAlHasib, 1440, true},
+	}
+
+	for _, test := range tests {
+		if got := (Tabular{test.leap}).IsLeap(test.year); got != test.want {
+			t.Errorf("%d: got %v, want %v", test.year, got, test.want)
+		}
+	}
+
+	// every pattern has 10631 days in 30 years
+	for leap := LeapYears16; leap <= HabashAlHasib; leap++ {
+		calendar := Tabular{leap}
+		start, _ := calendar.MonthStart(1441, Muharram)
+		end, _ := calendar.MonthStart(1471, Muharram)
+		if days := time_to_day(end) - time_to_day(start); days != 10631 {
+			t.Errorf("%d: got %d days", leap, days)
+		}
+	}
+}
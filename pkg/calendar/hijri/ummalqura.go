@@ -0,0 +1,92 @@
+package hijri
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+//go:embed data/ummalqura.csv
+var ummAlQuraCSV string
+
+var (
+	// the first days of the months from 1 Muharram 1356 to 1 Muharram 1501, as day numbers
+	// counted from 1 Muharram 1 of the tabular calendar
+	ummAlQuraMonths     []int
+	ummAlQuraFirstYear  int
+	ummAlQuraMonthsOnce sync.Once
+)
+
+// Read the embedded table of the Umm al-Qura calendar
+func load_umm_al_qura() ([]int, int, error) {
+	reader := csv.NewReader(strings.NewReader(ummAlQuraCSV))
+	reader.Comment = '#'
+	reader.FieldsPerRecord = 14
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var months []int
+	first, _ := strconv.Atoi(records[0][0])
+	for i, record := range records {
+		if year, err := strconv.Atoi(record[0]); err != nil || year != first+i {
+			return nil, 0, fmt.Errorf("year %s: expected %d", record[0], first+i)
+		}
+		start, err := time.Parse(time.DateOnly, record[1])
+		if err != nil {
+			return nil, 0, fmt.Errorf("year %s: %w", record[0], err)
+		}
+		day := time_to_day(start)
+		switch {
+		case i == 0:
+			months = append(months, day)
+		case months[len(months)-1] != day:
+			return nil, 0, fmt.Errorf("year %s: starts on %s, not after the previous year", record[0], record[1])
+		}
+		for _, field := range record[2:] {
+			// the calendar of the early years was irregular, Sha'ban 1364 had 28 days
+			length, err := strconv.Atoi(field)
+			if err != nil || length < 28 || length > 30 {
+				return nil, 0, fmt.Errorf("year %s: invalid month length %q", record[0], field)
+			}
+			day += length
+			months = append(months, day)
+		}
+	}
+	return months, first, nil
+}
+
+// UmmAlQura is the official calendar of Saudi Arabia. Its months start on the day after
+// the evening on which the moon sets after the sun in Mecca, with the conjunction before
+// sunset, so it anticipates the sighting of the crescent by about a day.
+//
+// The table covers the years 1356 to 1500, from 14 March 1937 to 16 November 2077. Dates
+// outside of it return ErrOutOfRange.
+type UmmAlQura struct{}
+
+// MonthStart returns the first day of a month as midnight UTC of its Gregorian date, see
+// Calendar.
+func (UmmAlQura) MonthStart(year int, month Month) (time.Time, error) {
+	ummAlQuraMonthsOnce.Do(func() {
+		months, first, err := load_umm_al_qura()
+		if err != nil {
+			panic(fmt.Sprintf("embedded Umm al-Qura table: %v", err))
+		}
+		ummAlQuraMonths, ummAlQuraFirstYear = months, first
+	})
+
+	if month < Muharram || month > DhuAlHijjah {
+		return time.Time{}, ErrInvalid
+	}
+	// the table has the start of the month after the last, which ends the last month
+	i := (year-ummAlQuraFirstYear)*12 + int(month-1)
+	if i < 0 || i >= len(ummAlQuraMonths) {
+		return time.Time{}, ErrOutOfRange
+	}
+	return day_to_time(ummAlQuraMonths[i]), nil
+}
@@ -0,0 +1,71 @@
+package hijri
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUmmAlQura(t *testing.T) {
+	var tests = []struct {
+		date time.Time
+		want Date
+	}{
+		{time.Date(1937, 3, 14, 0, 0, 0, 0, time.UTC), Date{1356, Muharram, 1}},
+		{time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC), Date{1410, JumadaAlAkhirah, 4}},
+		{time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC), Date{1445, Ramadan, 1}},
+		// the day of Arafah and Eid al-Adha 1445
+		{time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC), Date{1445, DhuAlHijjah, 9}},
+		{time.Date(2024, 6, 16, 0, 0, 0, 0, time.UTC), Date{1445, DhuAlHijjah, 10}},
+		{time.Date(2024, 7, 7, 0, 0, 0, 0, time.UTC), Date{1446, Muharram, 1}},
+		{time.Date(2050, 12, 31, 0, 0, 0, 0, time.UTC), Date{1473, RabiAlThani, 17}},
+		{time.Date(2077, 11, 16, 0, 0, 0, 0, time.UTC), Date{1500, DhuAlHijjah, 30}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.want.String(), func(t *testing.T) {
+			got, err := FromTime(UmmAlQura{}, test.date)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+			date, err := ToTime(UmmAlQura{}, test.want, time.UTC)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !date.Equal(test.date) {
+				t.Errorf("got %v, want %v", date, test.date)
+			}
+		})
+	}
+}
+
+func TestUmmAlQuraRange(t *testing.T) {
+	for _, date := range []time.Time{
+		time.Date(1937, 3, 13, 0, 0, 0, 0, time.UTC),
+		time.Date(2077, 11, 17, 0, 0, 0, 0, time.UTC),
+	} {
+		if _, err := FromTime(UmmAlQura{}, date); err != ErrOutOfRange {
+			t.Errorf("%v: got %v", date, err)
+		}
+	}
+
+	// every day of the table converts back to itself
+	start := time.Date(1937, 3, 14, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2077, 11, 17, 0, 0, 0, 0, time.UTC)
+	previous := Date{1355, DhuAlHijjah, 29}
+	for date := start; date.Before(end); date = date.AddDate(0, 0, 1) {
+		got, err := FromTime(UmmAlQura{}, date)
+		if err != nil {
+			t.Fatalf("%v: %v", date, err)
+		}
+		if got.Day != previous.Day+1 && (got.Day != 1 || previous.Day < 28) {
+			t.Fatalf("%v: %v follows %v", date, got, previous)
+		}
+		if back, _ := ToTime(UmmAlQura{}, got, time.UTC); !back.Equal(date) {
+			t.Fatalf("%v: %v converts to %v", date, got, back)
+		}
+		previous = got
+	}
+}
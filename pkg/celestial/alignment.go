@@ -0,0 +1,176 @@
+package celestial
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// The largest angular distance, in degrees, at which a closest approach of
+// the sun to the target direction is still reported as an alignment.
+const sunAlignmentTolerance = 1.0
+
+// Calculate the angular distance between two points given in horizontal coordinates.
+// Args:
+//
+//	elevation1, azimuth1: The first point in degrees
+//	elevation2, azimuth2: The second point in degrees
+//
+// Returns:
+//
+//	The angular separation in degrees.
+func angular_separation(elevation1, azimuth1, elevation2, azimuth2 float64) float64 {
+	e1 := radians(elevation1)
+	e2 := radians(elevation2)
+	da := radians(azimuth1 - azimuth2)
+
+	// Vincenty's formula is well conditioned for small and large separations alike
+	num := math.Hypot(math.Cos(e2)*math.Sin(da), math.Cos(e1)*math.Sin(e2)-math.Sin(e1)*math.Cos(e2)*math.Cos(da))
+	den := math.Sin(e1)*math.Sin(e2) + math.Cos(e1)*math.Cos(e2)*math.Cos(da)
+	return degrees(math.Atan2(num, den))
+}
+
+// AlignmentError calculates how far the sun is from the specified direction.
+// Args:
+//
+//	observer:    Observer to calculate for
+//	dateandtime: The date and time for which to calculate the error.
+//	bearing:     Azimuth of the direction in degrees clockwise from North
+//	elevation:   Elevation of the direction in degrees above the horizon
+//
+// Returns:
+//
+//	The angular distance in degrees between the refracted sun and the direction.
+func AlignmentError(observer Observer, dateandtime time.Time, bearing float64, elevation float64) float64 {
+	zenith, azimuth := ZenithAndAzimuth(observer, dateandtime, true)
+	return angular_separation(90-zenith, azimuth, elevation, bearing)
+}
+
+// SunAlignments finds the times when the sun sits at the specified azimuth and
+// elevation, e.g. when it sets along a street grid or shines through a window.
+//
+// Every day the sun passes the azimuth at most once rising and once setting.
+// The elevation it has at that moment drifts from day to day and an alignment is
+// the day on which it comes closest to the requested elevation. Use AlignmentError
+// for the angular distance that remains at each of the returned times.
+// Args:
+//
+//	observer:  Observer to calculate for
+//	bearing:   Azimuth in degrees clockwise from North
+//	elevation: Elevation in degrees above the horizon
+//	from:      Start of the period to search
+//	to:        End of the period to search
+//
+// Returns:
+//
+//	The times, in the timezone of from, of the closest approaches that come within
+//	a degree of the direction, in chronological order.
+func SunAlignments(observer Observer, bearing float64, elevation float64, from, to time.Time) []time.Time {
+	type candidate struct {
+		t   time.Time
+		err float64
+	}
+
+	// crossings of the rising and the setting sun drift independently of each other
+	var rising, setting []candidate
+	for _, t := range sun_azimuth_crossings(observer, bearing, from, to) {
+		c := candidate{t: t, err: AlignmentError(observer, t, bearing, elevation)}
+		if Elevation(observer, t.Add(time.Minute), false) > Elevation(observer, t, false) {
+			rising = append(rising, c)
+		} else {
+			setting = append(setting, c)
+		}
+	}
+
+	var alignments []time.Time
+	for _, branch := range [][]candidate{rising, setting} {
+		for i, c := range branch {
+			if c.err > sunAlignmentTolerance {
+				continue
+			}
+			if i > 0 && branch[i-1].err < c.err {
+				continue
+			}
+			if i < len(branch)-1 && branch[i+1].err <= c.err {
+				continue
+			}
+			alignments = append(alignments, c.t.In(from.Location()))
+		}
+	}
+
+	sort.Slice(alignments, func(i, j int) bool {
+		return alignments[i].Before(alignments[j])
+	})
+	return alignments
+}
+
+// MoonAlignment describes an opportunity to see the moon behind a landmark.
+type MoonAlignment struct {
+	// Time of the closest approach of the moon's centre to the landmark
+	Time time.Time
+	// Elevation of the moon in degrees above the horizon, refraction included
+	Elevation float64
+	// Azimuth of the moon in degrees clockwise from North
+	Azimuth float64
+	// Separation in degrees between the centre of the moon and the landmark
+	Separation float64
+	// AngularDiameter of the moon in degrees as seen by the observer
+	AngularDiameter float64
+	// Phase of the moon as returned by MoonPhase
+	Phase float64
+	// Illumination is the illuminated fraction of the moon's disk
+	Illumination float64
+}
+
+// MoonAlignments finds the times when the moon, as seen by the observer, sits
+// behind a landmark, e.g. for photographing the moon over a tower or a summit.
+// Args:
+//
+//	observer: Observer to calculate for
+//	landmark: Location of the landmark; its Elevation is the height of the point to aim at
+//	from:     Start of the period to search
+//	to:       End of the period to search
+//
+// Returns:
+//
+//	The closest approaches at which the landmark lies on the moon's disk, in chronological order.
+//	Times are in the timezone of from.
+func MoonAlignments(observer Observer, landmark Observer, from, to time.Time) []MoonAlignment {
+	const step = 4 * time.Minute
+
+	bearing := observer.Bearing(landmark)
+	elevation := observer.ElevationAngle(landmark)
+
+	separationAt := func(t time.Time) float64 {
+		zenith, azimuth := MoonZenithAndAzimuth(observer, t, true)
+		return angular_separation(90-zenith, azimuth, elevation, bearing)
+	}
+
+	var alignments []MoonAlignment
+	previous, current := math.Inf(1), separationAt(from)
+	for t := from; !t.After(to); t = t.Add(step) {
+		next := separationAt(t.Add(step))
+
+		// the moon moves about a degree per step, so anything further off can't be a hit
+		if current <= previous && current < next && current < 2 {
+			best := minimize_time(separationAt, t.Add(-step), t.Add(step), time.Second)
+
+			moonElevation, moonAzimuth, distance := moon_topocentric(observer, best)
+			radius := moon_apparent_radius(distance)
+			separation := separationAt(best)
+			if separation <= radius && !best.Before(from) && !best.After(to) {
+				alignments = append(alignments, MoonAlignment{
+					Time:            best.In(from.Location()),
+					Elevation:       moonElevation + refraction_at_zenith(90-moonElevation),
+					Azimuth:         moonAzimuth,
+					Separation:      separation,
+					AngularDiameter: 2 * radius,
+					Phase:           MoonPhase(best),
+					Illumination:    MoonIllumination(best),
+				})
+			}
+		}
+		previous, current = current, next
+	}
+	return alignments
+}
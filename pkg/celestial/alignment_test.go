@@ -0,0 +1,84 @@
+package celestial
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAngularSeparation(t *testing.T) {
+	type args struct {
+		elevation1 float64
+		azimuth1   float64
+		elevation2 float64
+		azimuth2   float64
+	}
+	tests := []struct {
+		name string
+		args args
+		want float64
+	}{
+		{args: args{elevation1: 10, azimuth1: 100, elevation2: 10, azimuth2: 100}, want: 0},
+		{args: args{elevation1: 0, azimuth1: 350, elevation2: 0, azimuth2: 10}, want: 20},
+		{args: args{elevation1: -30, azimuth1: 0, elevation2: 60, azimuth2: 0}, want: 90},
+		{args: args{elevation1: 90, azimuth1: 0, elevation2: 45, azimuth2: 123}, want: 45},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := angular_separation(tt.args.elevation1, tt.args.azimuth1, tt.args.elevation2, tt.args.azimuth2)
+			almostEqualFloat(t, got, tt.want, 0.0000001)
+		})
+	}
+}
+
+func TestSunAlignments(t *testing.T) {
+	// Manhattanhenge along 34th Street
+	manhattan := Observer{Latitude: 40.7484, Longitude: -73.9857}
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	got := SunAlignments(manhattan, 299, 0.27, from, to)
+	want := []time.Time{
+		time.Date(2024, 5, 27, 0, 13, 0, 0, time.UTC),
+		time.Date(2024, 7, 16, 0, 22, 0, 0, time.UTC),
+	}
+	if len(got) != len(want) {
+		t.Fatalf("SunAlignments() = %v, want %v", got, want)
+	}
+	for i := range got {
+		almostEqualTime(t, got[i], want[i], 3*time.Minute)
+		if e := AlignmentError(manhattan, got[i], 299, 0.27); e > sunAlignmentTolerance {
+			t.Fatalf("AlignmentError() = %v", e)
+		}
+	}
+
+	// the sun never stands in the north at noon in London
+	if got := SunAlignments(london, 0, 45, from, to); len(got) != 0 {
+		t.Fatalf("SunAlignments() = %v, want none", got)
+	}
+}
+
+func TestMoonAlignments(t *testing.T) {
+	// a mast on a hill about 3.5 km east of the observer
+	landmark := Observer{Latitude: london.Latitude, Longitude: london.Longitude + 0.05, Elevation: 300}
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	got := MoonAlignments(london, landmark, from, to)
+	want := []time.Time{
+		time.Date(2024, 2, 13, 9, 29, 0, 0, time.UTC),
+		time.Date(2024, 7, 11, 10, 29, 0, 0, time.UTC),
+		time.Date(2024, 10, 1, 5, 4, 0, 0, time.UTC),
+	}
+	if len(got) != len(want) {
+		t.Fatalf("MoonAlignments() = %v, want %v", got, want)
+	}
+	for i := range got {
+		almostEqualTime(t, got[i].Time, want[i], time.Minute)
+		almostEqualFloat(t, got[i].Azimuth, london.Bearing(landmark), 0.3)
+		almostEqualFloat(t, got[i].Elevation, london.ElevationAngle(landmark), 0.3)
+		if got[i].Separation > got[i].AngularDiameter/2 {
+			t.Fatalf("separation %v is larger than the moon's radius %v", got[i].Separation, got[i].AngularDiameter/2)
+		}
+	}
+	almostEqualFloat(t, got[0].Illumination, 0.166, 0.01)
+}
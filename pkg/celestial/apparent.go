@@ -0,0 +1,238 @@
+package celestial
+
+import (
+	"math"
+	"time"
+)
+
+// The constant of aberration in degrees
+const aberrationConstant = 20.49552 / 3600
+
+// Twice the gravitational parameter of the sun divided by the square of the speed of light, in AU
+const solarDeflection = 1.97412574e-8
+
+// A rectangular vector referred to the mean ecliptic and equinox of date
+type vec3 [3]float64
+
+func (a vec3) add(b vec3) vec3 {
+	return vec3{a[0] + b[0], a[1] + b[1], a[2] + b[2]}
+}
+
+func (a vec3) sub(b vec3) vec3 {
+	return vec3{a[0] - b[0], a[1] - b[1], a[2] - b[2]}
+}
+
+func (a vec3) scale(f float64) vec3 {
+	return vec3{a[0] * f, a[1] * f, a[2] * f}
+}
+
+func (a vec3) dot(b vec3) float64 {
+	return a[0]*b[0] + a[1]*b[1] + a[2]*b[2]
+}
+
+func (a vec3) length() float64 {
+	return math.Sqrt(a.dot(a))
+}
+
+// The longitude and latitude of the vector in degrees and its length
+func (a vec3) spherical() (float64, float64, float64) {
+	r := a.length()
+	return properAngle(degrees(math.Atan2(a[1], a[0]))), degrees(math.Asin(clamp(a[2]/r, -1, 1))), r
+}
+
+func spherical_vector(longitude, latitude, distance float64) vec3 {
+	x, y, z := ecliptic_rectangular(longitude, latitude, distance)
+	return vec3{x, y, z}
+}
+
+// Calculate the heliocentric position of the earth in the FK5 system
+// See Meeus, Astronomical Algorithms, chapter 25
+func earth_position(juliancentury float64) vec3 {
+	l, b, r := earth_heliocentric(juliancentury)
+	lp := radians(l + 180 - 1.397*juliancentury - 0.00031*juliancentury*juliancentury)
+	l -= 0.09033 / 3600
+	b -= 0.03916 / 3600 * (math.Cos(lp) - math.Sin(lp))
+	return spherical_vector(l, b, r)
+}
+
+// Calculate the heliocentric position of the earth in AU and its velocity in AU per day
+func earth_state(juliancentury float64) (vec3, vec3) {
+	const step = 0.05
+	before := earth_position(juliancentury - step/36525)
+	after := earth_position(juliancentury + step/36525)
+	return earth_position(juliancentury), after.sub(before).scale(1 / (2 * step))
+}
+
+// Bend the light of a body around the sun
+//
+// See Kaplan et al., Astronomical Journal 97, 1197 (1989)
+// Args:
+//
+//	direction: The unit vector from the earth to the body
+//	body:      The unit vector from the sun to the body
+//	earth:     The heliocentric position of the earth in AU
+//
+// Returns:
+//
+//	The deflected unit vector.
+func deflect(direction, body, earth vec3) vec3 {
+	distance := earth.length()
+	e := earth.scale(1 / distance)
+	g := 1 + body.dot(e)
+	if g < 1e-9 {
+		// the body is right behind the sun
+		return direction
+	}
+	bend := e.scale(direction.dot(body)).sub(body.scale(e.dot(direction)))
+	deflected := direction.add(bend.scale(solarDeflection / distance / g))
+	return deflected.scale(1 / deflected.length())
+}
+
+// Shift the direction to a body by the annual aberration, relativistically
+//
+// See Kaplan et al., Astronomical Journal 97, 1197 (1989)
+// Args:
+//
+//	direction: The unit vector from the earth to the body
+//	velocity:  The velocity of the earth in units of the speed of light
+//
+// Returns:
+//
+//	The aberrated unit vector.
+func aberrate(direction, velocity vec3) vec3 {
+	gamma := math.Sqrt(1 - velocity.dot(velocity))
+	f1 := direction.dot(velocity)
+	f2 := 1 + f1/(1+gamma)
+	aberrated := direction.scale(gamma).add(velocity.scale(f2)).scale(1 / (1 + f1))
+	return aberrated.scale(1 / aberrated.length())
+}
+
+// Place is the position of a body on the sky at one stage of the reduction from its
+// geometric position to the position seen by an observer.
+type Place struct {
+	RightAscension float64
+	Declination    float64
+	// Distance from the earth or the observer in AU, zero for stars
+	Distance float64
+}
+
+// Pipeline selects the corrections that reduce the geometric position of a body to a place
+// on the sky. Each step can be switched on by itself, Astrometric and Apparent are the
+// usual combinations.
+type Pipeline struct {
+	// LightTime places a planet where it was when the light left it
+	LightTime bool
+	// Deflection bends the light of the body around the sun
+	Deflection bool
+	// Aberration shifts the body towards the direction of the earth's motion
+	Aberration bool
+	// OfDate refers the place to the true equator and equinox of date, that is precessed
+	// and nutated, instead of the mean equator and equinox of J2000.0
+	OfDate bool
+	// Observer makes the place topocentric, corrected for the parallax, when set
+	Observer *Observer
+}
+
+var (
+	// Astrometric places are corrected for light-time only and referred to J2000.0, which
+	// makes them comparable with star catalogues
+	Astrometric = Pipeline{LightTime: true}
+	// Apparent places are the geocentric positions seen on the sky, referred to the true
+	// equator and equinox of date
+	Apparent = Pipeline{LightTime: true, Deflection: true, Aberration: true, OfDate: true}
+)
+
+// Topocentric returns a copy of the pipeline that also corrects for the parallax of an observer.
+func (p Pipeline) Topocentric(observer Observer) Pipeline {
+	p.Observer = &observer
+	return p
+}
+
+// Apply the deflection and the aberration to the geocentric position of a body
+// Args:
+//
+//	geocentric: The position relative to the earth, in AU for the solar system
+//	helio:      The direction from the sun to the body, zero for the sun itself
+//	earth:      The heliocentric position of the earth in AU
+//	velocity:   The velocity of the earth in AU per day
+//
+// Returns:
+//
+//	The unit vector in the direction of the body, referred to the mean ecliptic of date.
+func (p Pipeline) direction(geocentric, helio, earth, velocity vec3) vec3 {
+	u := geocentric.scale(1 / geocentric.length())
+	if p.Deflection && helio != (vec3{}) {
+		u = deflect(u, helio.scale(1/helio.length()), earth)
+	}
+	if p.Aberration {
+		u = aberrate(u, velocity.scale(lightTimePerAU))
+	}
+	return u
+}
+
+// Turn a direction referred to the mean ecliptic of date into a place in the chosen frame
+func (p Pipeline) place(dateandtime time.Time, juliancentury float64, direction vec3, distance float64) Place {
+	psi, eps := nutation(juliancentury)
+	obliquity := mean_obliquity_of_ecliptic(juliancentury)
+	longitude, latitude, _ := direction.spherical()
+	ra, dec := ecliptic_to_equatorial(longitude+psi, latitude, obliquity+eps)
+
+	if p.Observer != nil && distance > 0 {
+		lst := greenwich_apparent_sidereal_time(julian_datetime(dateandtime)) + p.Observer.Longitude
+		ra, dec, distance = equatorial_to_topocentric(*p.Observer, lst, ra, dec, distance*AstronomicalUnit)
+		distance /= AstronomicalUnit
+	}
+
+	if !p.OfDate {
+		longitude, latitude = equatorial_to_ecliptic(ra, dec, obliquity+eps)
+		ra, dec = ecliptic_to_equatorial(longitude-psi, latitude, obliquity)
+		ra, dec = precess_equatorial_to_j2000(juliancentury, ra, dec)
+	}
+	return Place{RightAscension: ra, Declination: dec, Distance: distance}
+}
+
+// Calculate the geocentric position of a planet, optionally at the time the light left it
+// Returns:
+//
+//	The geocentric and the heliocentric position in AU.
+func planet_geocentric(planet Planet, juliancentury float64, earth vec3, lightTime bool) (vec3, vec3) {
+	var geocentric, helio vec3
+	tau := 0.0
+	for i := 0; i < 3; i++ {
+		helio = spherical_vector(planet_heliocentric(planet, juliancentury-tau/36525))
+		geocentric = helio.sub(earth)
+		if !lightTime {
+			break
+		}
+		tau = lightTimePerAU * geocentric.length()
+	}
+	return geocentric, helio
+}
+
+// Planet calculates the place of a planet.
+func (p Pipeline) Planet(planet Planet, dateandtime time.Time) Place {
+	jc := jday_to_jcentury(julian_ephemeris_day(dateandtime))
+	earth, velocity := earth_state(jc)
+	geocentric, helio := planet_geocentric(planet, jc, earth, p.LightTime)
+	return p.place(dateandtime, jc, p.direction(geocentric, helio, earth, velocity), geocentric.length())
+}
+
+// Sun calculates the place of the sun. The light-time does not apply to it, its aberration
+// is the familiar 20.5" lag behind its geometric longitude.
+func (p Pipeline) Sun(dateandtime time.Time) Place {
+	jc := jday_to_jcentury(julian_ephemeris_day(dateandtime))
+	earth, velocity := earth_state(jc)
+	geocentric := earth.scale(-1)
+	return p.place(dateandtime, jc, p.direction(geocentric, vec3{}, earth, velocity), geocentric.length())
+}
+
+// Star calculates the place of a catalogue star, including its proper motion. Stars are
+// too far away for light-time and parallax to matter.
+func (p Pipeline) Star(star CatalogStar, dateandtime time.Time) Place {
+	jc := jday_to_jcentury(julian_ephemeris_day(dateandtime))
+	earth, velocity := earth_state(jc)
+	ra, dec := star_mean_place(star, jc)
+	longitude, latitude := equatorial_to_ecliptic(ra, dec, mean_obliquity_of_ecliptic(jc))
+	u := spherical_vector(longitude, latitude, 1)
+	return p.place(dateandtime, jc, p.direction(u, u, earth, velocity), 0)
+}
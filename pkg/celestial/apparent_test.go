@@ -0,0 +1,114 @@
+package celestial
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestDeflect(t *testing.T) {
+	// the light of a star at an elongation ψ from the sun is bent away from it
+	// by 0.00407" / tan(ψ/2), 1.75" at the limb
+	earth := vec3{1, 0, 0}
+	for _, elongation := range []float64{0.2666, 5, 90, 150} {
+		sin, cos := math.Sincos(radians(elongation))
+		star := vec3{-cos, sin, 0}
+		deflected := deflect(star, star, earth)
+		got := degrees(math.Acos(clamp(deflected.dot(earth.scale(-1)), -1, 1))) - elongation
+		almostEqualFloat(t, got*3600, 0.0040720/math.Tan(radians(elongation/2)), 0.0001)
+	}
+}
+
+func TestAberrate(t *testing.T) {
+	// a body at right angles to the motion of the earth moves by the constant of aberration
+	velocity := vec3{0, radians(aberrationConstant), 0}
+	aberrated := aberrate(vec3{1, 0, 0}, velocity)
+	almostEqualFloat(t, degrees(math.Atan2(aberrated[1], aberrated[0]))*3600, 20.49552, 0.001)
+
+	aberrated = aberrate(vec3{0, 1, 0}, velocity)
+	almostEqualFloat(t, aberrated[0], 0, 1e-12)
+}
+
+func TestEarthState(t *testing.T) {
+	// the earth moves by 29.3 to 30.3 km/s
+	for _, jd := range []float64{2451545.0, 2451727.0, 2460000.5} {
+		position, velocity := earth_state(jday_to_jcentury(jd))
+		speed := velocity.length() * AstronomicalUnit / 86400
+		if speed < 29.2 || speed > 30.3 {
+			t.Errorf("got %f km/s", speed)
+		}
+		almostEqualFloat(t, position.dot(velocity), 0, 0.0003)
+	}
+}
+
+func TestPipelineStar(t *testing.T) {
+	star := CatalogStar{
+		Name:            "θ Persei",
+		RightAscension:  (2 + 44.0/60 + 11.986/3600) * 15,
+		Declination:     49 + 13.0/60 + 42.48/3600,
+		ProperMotionRA:  0.03425 * 15 * 1000 * math.Cos(radians(49+13.0/60+42.48/3600)),
+		ProperMotionDec: -89.5,
+	}
+
+	// the astrometric place at the epoch of the catalogue is the catalogue position
+	j2000 := time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC)
+	j2000 = j2000.Add(-time.Duration(delta_t(j2000) * float64(time.Second)))
+	place := Astrometric.Star(star, j2000)
+	almostEqualFloat(t, place.RightAscension, star.RightAscension, 1e-7)
+	almostEqualFloat(t, place.Declination, star.Declination, 1e-7)
+
+	// Meeus, Astronomical Algorithms, example 23.a, less 0.1" in right ascension
+	// for the IAU 2006 precession
+	dateandtime := jday_to_datetime(2462088.69)
+	dateandtime = dateandtime.Add(-time.Duration(delta_t(dateandtime) * float64(time.Second)))
+	place = Apparent.Star(star, dateandtime)
+	almostEqualFloat(t, place.RightAscension, (2+46.0/60+14.390/3600)*15, 0.00005)
+	almostEqualFloat(t, place.Declination, 49+21.0/60+7.45/3600, 0.00002)
+	if place.Distance != 0 {
+		t.Errorf("got distance %f, want 0", place.Distance)
+	}
+
+	ra, dec := star.Equatorial(dateandtime)
+	almostEqualFloat(t, ra, place.RightAscension, 1e-9)
+	almostEqualFloat(t, dec, place.Declination, 1e-9)
+}
+
+func TestPipelineSun(t *testing.T) {
+	// the sun lags 20.5" behind its geometric longitude
+	dateandtime := terrestrialTime(1992, 10, 13)
+	jc := jday_to_jcentury(julian_ephemeris_day(dateandtime))
+	apparent := Apparent.Sun(dateandtime)
+	geometric := Pipeline{OfDate: true}.Sun(dateandtime)
+	l1, _ := equatorial_to_ecliptic(apparent.RightAscension, apparent.Declination, obliquity_correction(jc))
+	l2, _ := equatorial_to_ecliptic(geometric.RightAscension, geometric.Declination, obliquity_correction(jc))
+	almostEqualFloat(t, (l1-l2)*3600, -20.4898/apparent.Distance, 0.05)
+	almostEqualFloat(t, apparent.Distance, 0.99760775, 0.000001)
+}
+
+func TestPipelinePlanet(t *testing.T) {
+	dateandtime := terrestrialTime(1992, 12, 20)
+	venus := PlanetCoordinates(Venus, dateandtime)
+
+	apparent := Apparent.Planet(Venus, dateandtime)
+	almostEqualFloat(t, apparent.RightAscension, venus.RightAscension, 1e-9)
+	almostEqualFloat(t, apparent.Declination, venus.Declination, 1e-9)
+	almostEqualFloat(t, apparent.Distance, venus.Distance, 1e-9)
+
+	// the geometric distance is that of the light-time, Meeus, Astronomical Algorithms, example 33.a
+	geometric := Pipeline{}.Planet(Venus, dateandtime)
+	almostEqualFloat(t, geometric.Distance, 0.910845, 0.0001)
+
+	// the astrometric place is 7 years of precession away from the apparent one
+	astrometric := Astrometric.Planet(Venus, dateandtime)
+	almostEqualFloat(t, angular_separation(astrometric.Declination, astrometric.RightAscension,
+		apparent.Declination, apparent.RightAscension), 0.1, 0.02)
+
+	// the parallax of Venus is a few arc seconds
+	palomar := Observer{Latitude: 33.356111, Longitude: -116.8625, Elevation: 1706}
+	topocentric := Apparent.Topocentric(palomar).Planet(Venus, dateandtime)
+	if topocentric.Distance == apparent.Distance {
+		t.Error("topocentric distance equals geocentric distance")
+	}
+	almostEqualFloat(t, angular_separation(topocentric.Declination, topocentric.RightAscension,
+		apparent.Declination, apparent.RightAscension), 0, 0.003)
+}
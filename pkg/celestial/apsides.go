@@ -0,0 +1,88 @@
+package celestial
+
+import (
+	"sort"
+	"time"
+)
+
+// Distances in km that are commonly used to call a full or new moon a supermoon or a
+// micromoon, see ClassifySyzygies
+const (
+	SupermoonDistance = 360000.0
+	MicromoonDistance = 405000.0
+)
+
+// LunarApsis is a perigee or apogee of the moon's orbit.
+type LunarApsis struct {
+	Time time.Time
+	// Distance between the centres of the earth and the moon in km
+	Distance float64
+	// Perigee is true for the closest point of the orbit and false for the apogee
+	Perigee bool
+}
+
+// LunarApsides finds the times at which the moon is closest to and farthest from the
+// earth, from the minima and maxima of its distance.
+// Args:
+//
+//	from, to: The time range to search
+//
+// Returns:
+//
+//	The perigees and apogees in the range in UTC, in order.
+func LunarApsides(from, to time.Time) []LunarApsis {
+	const step = 12 * time.Hour
+	var apsides []LunarApsis
+	for _, t := range local_minima(MoonDistance, from, to, step) {
+		apsides = append(apsides, LunarApsis{Time: t.UTC(), Distance: MoonDistance(t), Perigee: true})
+	}
+	for _, t := range local_minima(func(t time.Time) float64 { return -MoonDistance(t) }, from, to, step) {
+		apsides = append(apsides, LunarApsis{Time: t.UTC(), Distance: MoonDistance(t)})
+	}
+	sort.Slice(apsides, func(i, j int) bool { return apsides[i].Time.Before(apsides[j].Time) })
+	return apsides
+}
+
+// Syzygy is a new or full moon, when the sun, the earth and the moon line up, together with
+// the distance of the moon.
+type Syzygy struct {
+	LunarPhaseTime
+	// Distance between the centres of the earth and the moon in km
+	Distance float64
+	// Supermoon and Micromoon are set when the moon is closer or farther than the chosen
+	// distances
+	Supermoon bool
+	Micromoon bool
+}
+
+// ClassifySyzygies finds the new and full moons and labels those with the moon near its
+// perigee as supermoons and those near its apogee as micromoons.
+//
+// There is no agreed definition. SupermoonDistance and MicromoonDistance are popular fixed
+// limits, another one is within 90% of the closest perigee of the year, which can be built
+// from LunarApsides.
+// Args:
+//
+//	from, to:  The time range to search
+//	supermoon: The distance in km up to which a syzygy is a supermoon
+//	micromoon: The distance in km from which a syzygy is a micromoon
+//
+// Returns:
+//
+//	The new and full moons in the range in UTC, in order.
+func ClassifySyzygies(from, to time.Time, supermoon, micromoon float64) []Syzygy {
+	var syzygies []Syzygy
+	for _, phase := range LunarPhases(from, to) {
+		if phase.Phase != NewMoon && phase.Phase != FullMoon {
+			continue
+		}
+		distance := MoonDistance(phase.Time)
+		syzygies = append(syzygies, Syzygy{
+			LunarPhaseTime: phase,
+			Distance:       distance,
+			Supermoon:      distance <= supermoon,
+			Micromoon:      distance >= micromoon,
+		})
+	}
+	return syzygies
+}
@@ -0,0 +1,73 @@
+package celestial
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLunarApsides(t *testing.T) {
+	var tests = []LunarApsis{
+		// See Meeus, Astronomical Algorithms, example 50.a
+		{Time: time.Date(1988, 10, 7, 20, 29, 0, 0, time.UTC), Distance: 406000, Perigee: false},
+		// the closest perigee of 2024
+		{Time: time.Date(2024, 10, 17, 0, 51, 0, 0, time.UTC), Distance: 357173, Perigee: true},
+		{Time: time.Date(2024, 10, 29, 22, 51, 0, 0, time.UTC), Distance: 406160, Perigee: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Time.String(), func(t *testing.T) {
+			apsides := LunarApsides(test.Time.AddDate(0, 0, -5), test.Time.AddDate(0, 0, 5))
+			if len(apsides) != 1 || apsides[0].Perigee != test.Perigee {
+				t.Fatalf("apsides %v", apsides)
+			}
+			almostEqualTime(t, apsides[0].Time, test.Time, 10*time.Minute)
+			almostEqualFloat(t, apsides[0].Distance, test.Distance, 50)
+		})
+	}
+
+	apsides := LunarApsides(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	if len(apsides) != 27 {
+		t.Fatalf("got %d apsides", len(apsides))
+	}
+	for i := 1; i < len(apsides); i++ {
+		if apsides[i].Perigee == apsides[i-1].Perigee {
+			t.Fatalf("%v follows %v", apsides[i], apsides[i-1])
+		}
+	}
+}
+
+func TestClassifySyzygies(t *testing.T) {
+	syzygies := ClassifySyzygies(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), SupermoonDistance, MicromoonDistance)
+	if len(syzygies) != 25 {
+		t.Fatalf("got %d syzygies", len(syzygies))
+	}
+
+	var supermoons, micromoons []time.Time
+	for _, syzygy := range syzygies {
+		if syzygy.Phase != NewMoon && syzygy.Phase != FullMoon {
+			t.Fatalf("phase %v", syzygy.Phase)
+		}
+		if syzygy.Supermoon && syzygy.Phase == FullMoon {
+			supermoons = append(supermoons, syzygy.Time)
+		}
+		if syzygy.Micromoon && syzygy.Phase == FullMoon {
+			micromoons = append(micromoons, syzygy.Time)
+		}
+	}
+
+	// the full supermoons of September and October 2024 and the full micromoons of
+	// February and March
+	if len(supermoons) != 2 || supermoons[0].Month() != time.September || supermoons[1].Month() != time.October {
+		t.Fatalf("supermoons %v", supermoons)
+	}
+	if len(micromoons) != 2 || micromoons[0].Month() != time.February || micromoons[1].Month() != time.March {
+		t.Fatalf("micromoons %v", micromoons)
+	}
+
+	// every full moon is a supermoon by a generous limit
+	for _, syzygy := range ClassifySyzygies(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), 410000, 420000) {
+		if !syzygy.Supermoon || syzygy.Micromoon {
+			t.Fatalf("%v", syzygy)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package celestial
+
+import (
+	"math"
+	"time"
+)
+
+// Time between the samples when searching for conjunctions and elongations
+const conjunctionStep = 6 * time.Hour
+
+// Conjunction is a moment at which two bodies come closest to each other on the sky.
+type Conjunction struct {
+	Time time.Time
+	// Separation is the geocentric angular distance between the bodies in degrees
+	Separation float64
+}
+
+// Separation calculates the geocentric angular distance between two bodies.
+// Returns:
+//
+//	The separation in degrees.
+func Separation(a, b Body, dateandtime time.Time) float64 {
+	ra1, dec1 := a.Equatorial(dateandtime)
+	ra2, dec2 := b.Equatorial(dateandtime)
+	return angular_separation(dec1, ra1, dec2, ra2)
+}
+
+// Find the local extremes of a function that is sampled at the given step. Every
+// bracketed extreme is refined to the minute. Maxima are found by negating f.
+func local_minima(f func(time.Time) float64, from, to time.Time, step time.Duration) []time.Time {
+	var minima []time.Time
+	t0, t1 := from, from.Add(step)
+	f0, f1 := f(t0), f(t1)
+	for t2 := t1.Add(step); !t2.After(to.Add(step)); t2 = t2.Add(step) {
+		f2 := f(t2)
+		if f1 < f0 && f1 <= f2 {
+			t := minimize_time(f, t0, t2, time.Minute)
+			if !t.Before(from) && !t.After(to) {
+				minima = append(minima, t)
+			}
+		}
+		t0, t1, f0, f1 = t1, t2, f1, f2
+	}
+	return minima
+}
+
+// Conjunctions finds the times at which two bodies pass each other on the sky.
+// Args:
+//
+//	a, b:          The bodies, e.g. Moon and Jupiter, or two planets
+//	from, to:      The time range to search
+//	maxSeparation: The largest separation in degrees to report
+//
+// Returns:
+//
+//	The times of the smallest geocentric separation with the separation itself.
+func Conjunctions(a, b Body, from, to time.Time, maxSeparation float64) []Conjunction {
+	separation := func(t time.Time) float64 {
+		return Separation(a, b, t)
+	}
+
+	var conjunctions []Conjunction
+	for _, t := range local_minima(separation, from, to, conjunctionStep) {
+		if s := separation(t); s <= maxSeparation {
+			conjunctions = append(conjunctions, Conjunction{Time: t, Separation: s})
+		}
+	}
+	return conjunctions
+}
+
+// LunarOccultations finds the conjunctions of the moon with a body that are close enough
+// for the moon to cover the body as seen from somewhere on the earth. Whether and where
+// an occultation is visible depends on the observer, so these are candidates.
+// Args:
+//
+//	body:     The body that may be occulted, e.g. a planet or a CatalogStar
+//	from, to: The time range to search
+//
+// Returns:
+//
+//	The conjunctions with a geocentric separation smaller than the moon's horizontal
+//	parallax plus its semi-diameter.
+func LunarOccultations(body Body, from, to time.Time) []Conjunction {
+	var occultations []Conjunction
+	for _, conjunction := range Conjunctions(Moon, body, from, to, 1.6) {
+		distance := MoonDistance(conjunction.Time)
+		parallax := degrees(math.Asin(earthEquatorialRadius / distance))
+		if conjunction.Separation < parallax+moon_apparent_radius(distance) {
+			occultations = append(occultations, conjunction)
+		}
+	}
+	return occultations
+}
+
+// The difference between the apparent geocentric longitudes of a planet and the sun
+// in degrees, in the range (-180, 180], positive when the planet is east of the sun
+func planet_sun_longitude(planet Planet, dateandtime time.Time) float64 {
+	return angle_difference(planet_position(planet, dateandtime).Longitude, SunApparentLongitude(dateandtime))
+}
+
+// Oppositions finds the times at which a planet stands opposite the sun, i.e. when the
+// difference between their apparent geocentric longitudes is 180 degrees.
+// Args:
+//
+//	planet:   Mars or a planet further out
+//	from, to: The time range to search
+//
+// Returns:
+//
+//	The times of opposition. Mercury and Venus are never in opposition.
+func Oppositions(planet Planet, from, to time.Time) []time.Time {
+	// the planet falls behind the sun, so the difference wraps from -180 to 180 at opposition
+	var oppositions []time.Time
+	t0, d0 := from, planet_sun_longitude(planet, from)
+	for t0.Before(to) {
+		t1 := t0.Add(24 * time.Hour)
+		d1 := planet_sun_longitude(planet, t1)
+		if d0 < -90 && d1 > 90 {
+			lo, hi := t0, t1
+			for hi.Sub(lo) > time.Minute {
+				mid := lo.Add(hi.Sub(lo) / 2)
+				if planet_sun_longitude(planet, mid) < 0 {
+					lo = mid
+				} else {
+					hi = mid
+				}
+			}
+			if t := lo.Round(time.Minute); !t.Before(from) && !t.After(to) {
+				oppositions = append(oppositions, t)
+			}
+		}
+		t0, d0 = t1, d1
+	}
+	return oppositions
+}
+
+// Elongation is a greatest elongation of an inner planet.
+type Elongation struct {
+	Time time.Time
+	// Elongation is the angle between the sun and the planet in degrees
+	Elongation float64
+	// East is true for an eastern elongation, when the planet is seen in the evening
+	East bool
+}
+
+// GreatestElongations finds the times at which Mercury or Venus stand farthest from the sun.
+// Args:
+//
+//	planet:   Mercury or Venus
+//	from, to: The time range to search
+//
+// Returns:
+//
+//	The greatest eastern (evening) and western (morning) elongations in the range.
+func GreatestElongations(planet Planet, from, to time.Time) []Elongation {
+	elongation := func(t time.Time) float64 {
+		return -planet_position(planet, t).Elongation
+	}
+
+	var elongations []Elongation
+	for _, t := range local_minima(elongation, from, to, conjunctionStep) {
+		elongations = append(elongations, Elongation{
+			Time:       t,
+			Elongation: -elongation(t),
+			East:       planet_sun_longitude(planet, t) > 0,
+		})
+	}
+	return elongations
+}
@@ -0,0 +1,109 @@
+package celestial
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSeparation(t *testing.T) {
+	dateandtime := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	almostEqualFloat(t, Separation(Jupiter, Jupiter, dateandtime), 0, 1e-9)
+	almostEqualFloat(t, Separation(Sun, Venus, dateandtime), PlanetCoordinates(Venus, dateandtime).Elongation, 0.02)
+}
+
+func TestConjunctions(t *testing.T) {
+	tests := []struct {
+		a, b       Body
+		from, to   time.Time
+		time       time.Time
+		separation float64
+	}{
+		// the great conjunction of Jupiter and Saturn
+		{Jupiter, Saturn, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2020, 12, 21, 18, 0, 0, 0, time.UTC), 0.10},
+		{Venus, Jupiter, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2023, 3, 2, 0, 0, 0, 0, time.UTC), 0.5},
+	}
+	for _, tt := range tests {
+		conjunctions := Conjunctions(tt.a, tt.b, tt.from, tt.to, 1)
+		if len(conjunctions) != 1 {
+			t.Fatalf("got %d conjunctions, want 1", len(conjunctions))
+		}
+		almostEqualTime(t, conjunctions[0].Time, tt.time, 12*time.Hour)
+		almostEqualFloat(t, conjunctions[0].Separation, tt.separation, 0.03)
+	}
+}
+
+func TestLunarOccultations(t *testing.T) {
+	// the moon occulted Saturn every month from April 2024
+	occultations := LunarOccultations(Saturn, time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	want := []time.Time{
+		time.Date(2024, 7, 24, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 8, 21, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 9, 17, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 10, 14, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 11, 10, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 12, 8, 0, 0, 0, 0, time.UTC),
+	}
+	if len(occultations) != len(want) {
+		t.Fatalf("got %d occultations, want %d", len(occultations), len(want))
+	}
+	for i := range want {
+		almostEqualTime(t, occultations[i].Time, want[i], 36*time.Hour)
+	}
+
+	// Regulus stays clear of the moon until the series of occultations starting in 2025
+	regulus, _ := FindStar("Regulus")
+	if got := LunarOccultations(regulus, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)); len(got) != 0 {
+		t.Errorf("got %d occultations of Regulus in 2023, want 0", len(got))
+	}
+}
+
+func TestOppositions(t *testing.T) {
+	tests := []struct {
+		planet   Planet
+		from, to time.Time
+		want     []time.Time
+	}{
+		{Mars, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			[]time.Time{time.Date(2020, 10, 13, 23, 20, 0, 0, time.UTC), time.Date(2022, 12, 8, 5, 36, 0, 0, time.UTC)}},
+		{Jupiter, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			[]time.Time{time.Date(2023, 11, 3, 5, 3, 0, 0, time.UTC)}},
+		{Saturn, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			[]time.Time{time.Date(2023, 8, 27, 8, 28, 0, 0, time.UTC)}},
+		{Venus, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), nil},
+	}
+	for _, tt := range tests {
+		got := Oppositions(tt.planet, tt.from, tt.to)
+		if len(got) != len(tt.want) {
+			t.Fatalf("%v: got %d oppositions, want %d", tt.planet, len(got), len(tt.want))
+		}
+		for i := range tt.want {
+			almostEqualTime(t, got[i], tt.want[i], 3*time.Hour)
+		}
+	}
+}
+
+func TestGreatestElongations(t *testing.T) {
+	// Mercury in 2024
+	want := []Elongation{
+		{time.Date(2024, 1, 12, 15, 0, 0, 0, time.UTC), 23.5, false},
+		{time.Date(2024, 3, 24, 23, 0, 0, 0, time.UTC), 18.7, true},
+		{time.Date(2024, 5, 9, 22, 0, 0, 0, time.UTC), 26.4, false},
+		{time.Date(2024, 7, 22, 7, 0, 0, 0, time.UTC), 26.9, true},
+		{time.Date(2024, 9, 5, 2, 0, 0, 0, time.UTC), 18.1, false},
+		{time.Date(2024, 11, 16, 8, 0, 0, 0, time.UTC), 22.5, true},
+		{time.Date(2024, 12, 25, 2, 0, 0, 0, time.UTC), 22.0, false},
+	}
+	got := GreatestElongations(Mercury, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	if len(got) != len(want) {
+		t.Fatalf("got %d elongations, want %d", len(got), len(want))
+	}
+	for i := range want {
+		almostEqualTime(t, got[i].Time, want[i].Time, 2*time.Hour)
+		almostEqualFloat(t, got[i].Elongation, want[i].Elongation, 0.1)
+		if got[i].East != want[i].East {
+			t.Errorf("%v: got East %v, want %v", want[i].Time, got[i].East, want[i].East)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package celestial
+
+import (
+	"math"
+)
+
+// Equatorial radius of the earth in kilometres, as used for parallaxes
+const earthEquatorialRadius = 6378.14
+
+// Convert ecliptic coordinates to equatorial coordinates
+// Args:
+//
+//	longitude: The ecliptic longitude in degrees
+//	latitude:  The ecliptic latitude in degrees
+//	obliquity: The obliquity of the ecliptic in degrees
+//
+// Returns:
+//
+//	The right ascension and declination in degrees.
+func ecliptic_to_equatorial(longitude, latitude, obliquity float64) (float64, float64) {
+	sinl, cosl := math.Sincos(radians(longitude))
+	sinb, cosb := math.Sincos(radians(latitude))
+	sine, cose := math.Sincos(radians(obliquity))
+
+	ra := degrees(math.Atan2(sinl*cose-(sinb/cosb)*sine, cosl))
+	dec := degrees(math.Asin(sinb*cose + cosb*sine*sinl))
+	return properAngle(ra), dec
+}
+
+// Convert equatorial coordinates to ecliptic coordinates
+// Args:
+//
+//	ra, dec:   The right ascension and declination in degrees
+//	obliquity: The obliquity of the ecliptic in degrees
+//
+// Returns:
+//
+//	The ecliptic longitude and latitude in degrees.
+func equatorial_to_ecliptic(ra, dec, obliquity float64) (float64, float64) {
+	sina, cosa := math.Sincos(radians(ra))
+	sind, cosd := math.Sincos(radians(dec))
+	sine, cose := math.Sincos(radians(obliquity))
+
+	longitude := degrees(math.Atan2(sina*cose+(sind/cosd)*sine, cosa))
+	latitude := degrees(math.Asin(sind*cose - cosd*sine*sina))
+	return properAngle(longitude), latitude
+}
+
+// Calculate the mean sidereal time at Greenwich
+//
+// See Meeus, Astronomical Algorithms, chapter 12
+// Args:
+//
+//	julianday: The Julian Day on the UT scale, including the fraction of the day
+//
+// Returns:
+//
+//	The sidereal time in degrees.
+func greenwich_mean_sidereal_time(julianday float64) float64 {
+	t := jday_to_jcentury(julianday)
+	theta := 280.46061837 + 360.98564736629*(julianday-2451545.0) + 0.000387933*t*t - t*t*t/38710000.0
+	return properAngle(theta)
+}
+
+// Calculate the apparent sidereal time at Greenwich, which includes the nutation
+// in right ascension (the equation of the equinoxes)
+func greenwich_apparent_sidereal_time(julianday float64) float64 {
+	jc := jday_to_jcentury(julianday)
+	return properAngle(greenwich_mean_sidereal_time(julianday) + nutation_in_longitude(jc)*math.Cos(radians(obliquity_correction(jc))))
+}
+
+// Calculate the geocentric position of an observer
+//
+// See Meeus, Astronomical Algorithms, chapter 11
+// Returns:
+//
+//	ρ sin φ' and ρ cos φ' in units of the earth's equatorial radius.
+func observer_geocentric(observer Observer) (float64, float64) {
+	const ba = 1 - wgs84Flattening
+
+	phi := radians(observer.Latitude)
+	u := math.Atan(ba * math.Tan(phi))
+	h := observer.Elevation / (earthEquatorialRadius * 1000)
+
+	rhoSin := ba*math.Sin(u) + h*math.Sin(phi)
+	rhoCos := math.Cos(u) + h*math.Cos(phi)
+	return rhoSin, rhoCos
+}
+
+// Convert geocentric equatorial coordinates to topocentric ones
+// Args:
+//
+//	observer:  Observer to calculate for
+//	lst:       The local sidereal time in degrees
+//	ra, dec:   The geocentric right ascension and declination in degrees
+//	distance:  The geocentric distance in kilometres
+//
+// Returns:
+//
+//	The topocentric right ascension, declination and distance.
+func equatorial_to_topocentric(observer Observer, lst, ra, dec, distance float64) (float64, float64, float64) {
+	rhoSin, rhoCos := observer_geocentric(observer)
+
+	sinra, cosra := math.Sincos(radians(ra))
+	sindec, cosdec := math.Sincos(radians(dec))
+	sinlst, coslst := math.Sincos(radians(lst))
+
+	x := distance*cosdec*cosra - earthEquatorialRadius*rhoCos*coslst
+	y := distance*cosdec*sinra - earthEquatorialRadius*rhoCos*sinlst
+	z := distance*sindec - earthEquatorialRadius*rhoSin
+
+	r := math.Sqrt(x*x + y*y + z*z)
+	return properAngle(degrees(math.Atan2(y, x))), degrees(math.Asin(z / r)), r
+}
+
+// Convert equatorial coordinates to horizontal coordinates
+// Args:
+//
+//	latitude:  The latitude of the observer in degrees
+//	hourangle: The local hour angle in degrees, positive west of the meridian
+//	dec:       The declination in degrees
+//
+// Returns:
+//
+//	The elevation above the horizon and the azimuth clockwise from North, both in degrees.
+func equatorial_to_horizontal(latitude, hourangle, dec float64) (float64, float64) {
+	sinphi, cosphi := math.Sincos(radians(latitude))
+	sinh, cosh := math.Sincos(radians(hourangle))
+	sindec, cosdec := math.Sincos(radians(dec))
+
+	elevation := degrees(math.Asin(clamp(sinphi*sindec+cosphi*cosdec*cosh, -1, 1)))
+	azimuth := degrees(math.Atan2(-cosdec*sinh, sindec*cosphi-cosdec*cosh*sinphi))
+	return elevation, properAngle(azimuth)
+}
@@ -0,0 +1,65 @@
+package celestial
+
+import (
+	"testing"
+)
+
+func TestEclipticToEquatorial(t *testing.T) {
+	// Meeus, Astronomical Algorithms, example 13.a
+	ra, dec := ecliptic_to_equatorial(113.215630, 6.684170, 23.4392911)
+	almostEqualFloat(t, ra, 116.328942, 0.000001)
+	almostEqualFloat(t, dec, 28.026183, 0.000001)
+}
+
+func TestEquatorialToEcliptic(t *testing.T) {
+	// Meeus, Astronomical Algorithms, example 13.a
+	longitude, latitude := equatorial_to_ecliptic(116.328942, 28.026183, 23.4392911)
+	almostEqualFloat(t, longitude, 113.215630, 0.000001)
+	almostEqualFloat(t, latitude, 6.684170, 0.000001)
+}
+
+func TestGreenwichMeanSiderealTime(t *testing.T) {
+	type args struct {
+		julianday float64
+	}
+	tests := []struct {
+		name string
+		args args
+		want float64
+	}{
+		// Meeus, Astronomical Algorithms, examples 12.a and 12.b
+		{args: args{julianday: 2446895.5}, want: 197.693195},
+		{args: args{julianday: 2446896.30625}, want: 128.7378734},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := greenwich_mean_sidereal_time(tt.args.julianday)
+			almostEqualFloat(t, got, tt.want, 0.000001)
+		})
+	}
+}
+
+func TestObserverGeocentric(t *testing.T) {
+	// Meeus, Astronomical Algorithms, example 11.a
+	palomar := Observer{Latitude: 33.356111, Longitude: -116.8625, Elevation: 1706}
+	rhoSin, rhoCos := observer_geocentric(palomar)
+	almostEqualFloat(t, rhoSin, 0.546861, 0.000001)
+	almostEqualFloat(t, rhoCos, 0.836339, 0.000001)
+}
+
+func TestEquatorialToTopocentric(t *testing.T) {
+	// Meeus, Astronomical Algorithms, example 40.a
+	palomar := Observer{Latitude: 33.356111, Longitude: -116.8625, Elevation: 1706}
+	ra := 339.530208
+	lst := 288.7958 + ra
+	gotRa, gotDec, _ := equatorial_to_topocentric(palomar, lst, ra, -15.771083, 0.37276*149597870.7)
+	almostEqualFloat(t, gotRa, 339.535583, 0.00005)
+	almostEqualFloat(t, gotDec, -15.775000, 0.00005)
+}
+
+func TestEquatorialToHorizontal(t *testing.T) {
+	// Meeus, Astronomical Algorithms, example 13.b
+	elevation, azimuth := equatorial_to_horizontal(38.921389, 64.352133, -6.719892)
+	almostEqualFloat(t, elevation, 15.1249, 0.0001)
+	almostEqualFloat(t, azimuth, 68.0337+180, 0.0001)
+}
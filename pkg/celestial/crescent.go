@@ -0,0 +1,260 @@
+package celestial
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// YallopClass is the visibility of the young crescent moon by Yallop's q-test.
+//
+// See Yallop, A Method for Predicting the First Sighting of the New Crescent Moon,
+// NAO Technical Note 69 (1997)
+type YallopClass byte
+
+const (
+	// YallopA is easily visible to the naked eye
+	YallopA YallopClass = 'A'
+	// YallopB is visible to the naked eye under perfect conditions
+	YallopB YallopClass = 'B'
+	// YallopC may need optical aid to find the crescent before it can be seen by eye
+	YallopC YallopClass = 'C'
+	// YallopD will need optical aid to find the crescent
+	YallopD YallopClass = 'D'
+	// YallopE is not visible with a telescope
+	YallopE YallopClass = 'E'
+	// YallopF is not visible, below the Danjon limit
+	YallopF YallopClass = 'F'
+)
+
+func (c YallopClass) String() string {
+	return string(c)
+}
+
+// Classify Yallop's q value
+func yallop_class(q float64) YallopClass {
+	switch {
+	case q > 0.216:
+		return YallopA
+	case q > -0.014:
+		return YallopB
+	case q > -0.160:
+		return YallopC
+	case q > -0.232:
+		return YallopD
+	case q > -0.293:
+		return YallopE
+	}
+	return YallopF
+}
+
+// OdehZone is the visibility of the young crescent moon by Odeh's criterion.
+//
+// See Odeh, New Criterion for Lunar Crescent Visibility, Experimental Astronomy 18 (2004)
+type OdehZone int
+
+const (
+	// OdehNakedEye is visible by the naked eye
+	OdehNakedEye OdehZone = iota
+	// OdehOpticalAidNakedEye is visible by optical aid and could be seen by the naked eye
+	OdehOpticalAidNakedEye
+	// OdehOpticalAid is visible by optical aid only
+	OdehOpticalAid
+	// OdehNotVisible is not visible even by optical aid
+	OdehNotVisible
+)
+
+func (z OdehZone) String() string {
+	switch z {
+	case OdehNakedEye:
+		return "visible by naked eye"
+	case OdehOpticalAidNakedEye:
+		return "visible by optical aid, could be seen by naked eye"
+	case OdehOpticalAid:
+		return "visible by optical aid only"
+	}
+	return "not visible"
+}
+
+// Classify Odeh's V value
+func odeh_zone(v float64) OdehZone {
+	switch {
+	case v >= 5.65:
+		return OdehNakedEye
+	case v >= 2:
+		return OdehOpticalAidNakedEye
+	case v >= -0.96:
+		return OdehOpticalAid
+	}
+	return OdehNotVisible
+}
+
+// The arc of vision at which a crescent of the given width in arc minutes becomes
+// visible, the polynomial shared by Yallop and Odeh without its constant term
+func crescent_arcv(width float64) float64 {
+	return -6.3226*width + 0.7319*width*width - 0.1018*width*width*width
+}
+
+// CrescentVisibility describes the young crescent moon on the evening after a new moon.
+// Angles are in degrees, the width is in arc minutes.
+type CrescentVisibility struct {
+	// NewMoon is the conjunction nearest to the sunset
+	NewMoon time.Time
+	Sunset  time.Time
+	// Moonset is zero if the moon does not set on that evening
+	Moonset time.Time
+	// Best is the best time to look for the crescent, four ninths of the lag after sunset
+	Best time.Time
+	// Age is the time from the new moon to the best time, negative before the conjunction
+	Age time.Duration
+	// Lag is the time from sunset to moonset, negative if the moon sets first
+	Lag time.Duration
+	// ARCL is the elongation of the moon from the sun, ARCV the difference in altitude
+	// and DAZ the difference in azimuth, sun minus moon, all geocentric and without
+	// refraction at the best time
+	ARCL float64
+	ARCV float64
+	DAZ  float64
+	// Width is the topocentric width of the crescent
+	Width float64
+	// Q and Yallop are the value and the class of Yallop's test
+	Q      float64
+	Yallop YallopClass
+	// V and Odeh are the value and the zone of Odeh's criterion, from the topocentric
+	// arc of light, arc of vision and width
+	V    float64
+	Odeh OdehZone
+}
+
+// Visible tells whether the crescent can be seen by the naked eye by both criteria, under
+// perfect conditions.
+func (c CrescentVisibility) Visible() bool {
+	return (c.Yallop == YallopA || c.Yallop == YallopB) && c.Odeh == OdehNakedEye
+}
+
+// Crescent calculates the visibility of the crescent moon in the evening of a day.
+//
+// The best time follows Yallop and Odeh, four ninths of the lag between sunset and moonset
+// after sunset. If the moon sets before the sun the crescent cannot be seen, the best time
+// is the sunset and the classes are F and not visible.
+// Args:
+//
+//	observer: Observer to calculate for
+//	date:     Date to calculate for, the day is taken in the date's timezone
+//
+// Returns:
+//
+//	The crescent in the evening of the day, or an error if the sun does not set.
+func Crescent(observer Observer, date time.Time) (CrescentVisibility, error) {
+	sunset, err := Sunset(observer, date)
+	if err != nil {
+		return CrescentVisibility{}, err
+	}
+
+	crescent := CrescentVisibility{NewMoon: moon_elongation_time(0, sunset).UTC(), Sunset: sunset, Best: sunset}
+	// the young moon sets soon after the sun, after midnight at the latest
+	for _, day := range []time.Time{date, date.AddDate(0, 0, 1)} {
+		moonset, err := Moonset(observer, day)
+		if err == nil && moonset.After(sunset.Add(-6*time.Hour)) {
+			crescent.Moonset = moonset
+			break
+		}
+	}
+	if !crescent.Moonset.IsZero() {
+		crescent.Lag = crescent.Moonset.Sub(sunset)
+		if crescent.Lag > 0 {
+			crescent.Best = sunset.Add(crescent.Lag * 4 / 9)
+		}
+	}
+	crescent.Age = crescent.Best.Sub(crescent.NewMoon)
+
+	best := crescent.Best
+	sunAltitude, sunAzimuth := body_horizontal(Sun, observer, best)
+	moonAltitude, moonAzimuth := body_horizontal(Moon, observer, best)
+	crescent.ARCL = Separation(Sun, Moon, best)
+	crescent.ARCV = moonAltitude - sunAltitude
+	crescent.DAZ = angle_difference(sunAzimuth, moonAzimuth)
+
+	// the width uses the semi-diameter seen by the observer
+	topoAltitude, topoAzimuth, distance := moon_topocentric(observer, best)
+	semidiameter := moon_apparent_radius(distance) * 60
+	crescent.Width = semidiameter * (1 - math.Cos(radians(crescent.ARCL)))
+	crescent.Q = (crescent.ARCV - (11.8371 + crescent_arcv(crescent.Width))) / 10
+
+	topoARCL := angular_separation(sunAltitude, sunAzimuth, topoAltitude, topoAzimuth)
+	topoWidth := semidiameter * (1 - math.Cos(radians(topoARCL)))
+	crescent.V = topoAltitude - sunAltitude - (7.1651 + crescent_arcv(topoWidth))
+
+	crescent.Yallop = yallop_class(crescent.Q)
+	crescent.Odeh = odeh_zone(crescent.V)
+	if crescent.Lag <= 0 {
+		crescent.Yallop, crescent.Odeh = YallopF, OdehNotVisible
+	}
+	return crescent, nil
+}
+
+// Crescents calculates the visibility of the crescent on the first evenings after every
+// new moon in a time range, the day of the new moon and the two following days.
+// Args:
+//
+//	observer: Observer to calculate for
+//	from, to: The time range in which to search new moons
+//	loc:      The timezone of the observer, which decides the days
+//
+// Returns:
+//
+//	Three evenings for every new moon, on which the sun sets, in order.
+func Crescents(observer Observer, from, to time.Time, loc *time.Location) []CrescentVisibility {
+	var crescents []CrescentVisibility
+	for _, phase := range LunarPhases(from, to) {
+		if phase.Phase != NewMoon {
+			continue
+		}
+		local := phase.Time.In(loc)
+		day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+		for i := 0; i < 3; i++ {
+			crescent, err := Crescent(observer, day.AddDate(0, 0, i))
+			if err == nil {
+				crescents = append(crescents, crescent)
+			}
+		}
+	}
+	return crescents
+}
+
+// CrescentGridPoint is the crescent visibility at one point of a map.
+type CrescentGridPoint struct {
+	Latitude  float64
+	Longitude float64
+	CrescentVisibility
+}
+
+// CrescentMap samples the crescent visibility on a latitude and longitude grid for a global
+// visibility map, as published for the Islamic months.
+// Args:
+//
+//	date: The date of the evening, the same calendar day everywhere
+//	step: The spacing of the grid in degrees, greater than 0
+//
+// Returns:
+//
+//	The grid points from south to north and west to east, without those where the sun
+//	does not set, or an error if the step is not positive.
+func CrescentMap(date time.Time, step float64) ([]CrescentGridPoint, error) {
+	// a step of 0 or NaN would never leave the loops
+	if !(step > 0) {
+		return nil, fmt.Errorf("invalid grid step %v", step)
+	}
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	var points []CrescentGridPoint
+	for latitude := -90 + step/2; latitude < 90; latitude += step {
+		for longitude := -180 + step/2; longitude < 180; longitude += step {
+			crescent, err := Crescent(Observer{Latitude: latitude, Longitude: longitude}, day)
+			if err != nil {
+				continue
+			}
+			points = append(points, CrescentGridPoint{Latitude: latitude, Longitude: longitude, CrescentVisibility: crescent})
+		}
+	}
+	return points, nil
+}
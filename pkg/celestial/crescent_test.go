@@ -0,0 +1,140 @@
+package celestial
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestYallopClass(t *testing.T) {
+	var tests = []struct {
+		q    float64
+		want YallopClass
+	}{
+		{0.5, YallopA},
+		{0.216, YallopB},
+		{0, YallopB},
+		{-0.1, YallopC},
+		{-0.2, YallopD},
+		{-0.25, YallopE},
+		{-0.293, YallopF},
+		{-1, YallopF},
+	}
+
+	for _, test := range tests {
+		if got := yallop_class(test.q); got != test.want {
+			t.Errorf("q %v: got %v, want %v", test.q, got, test.want)
+		}
+	}
+}
+
+func TestOdehZone(t *testing.T) {
+	var tests = []struct {
+		v    float64
+		want OdehZone
+	}{
+		{10, OdehNakedEye},
+		{5.65, OdehNakedEye},
+		{3, OdehOpticalAidNakedEye},
+		{2, OdehOpticalAidNakedEye},
+		{0, OdehOpticalAid},
+		{-0.96, OdehOpticalAid},
+		{-1, OdehNotVisible},
+	}
+
+	for _, test := range tests {
+		if got := odeh_zone(test.v); got != test.want {
+			t.Errorf("V %v: got %v, want %v", test.v, got, test.want)
+		}
+	}
+}
+
+func TestCrescent(t *testing.T) {
+	// the crescent of Shawwal 1445 after the new moon of 2024-04-08 18:21 UT
+	mecca := Observer{Latitude: 21.4225, Longitude: 39.8262}
+	loc := time.FixedZone("AST", 3*3600)
+	var tests = []struct {
+		day     int
+		age     time.Duration
+		lag     time.Duration
+		yallop  YallopClass
+		odeh    OdehZone
+		visible bool
+	}{
+		// the sun sets before the conjunction and the moon before the sun
+		{8, -163 * time.Minute, -11 * time.Minute, YallopF, OdehNotVisible, false},
+		{9, 1300 * time.Minute, 53 * time.Minute, YallopA, OdehNakedEye, true},
+		{10, 2770 * time.Minute, 118 * time.Minute, YallopA, OdehNakedEye, true},
+	}
+
+	for _, test := range tests {
+		t.Run(time.Date(2024, 4, test.day, 0, 0, 0, 0, loc).Format(time.DateOnly), func(t *testing.T) {
+			crescent, err := Crescent(mecca, time.Date(2024, 4, test.day, 0, 0, 0, 0, loc))
+			if err != nil {
+				t.Fatal(err)
+			}
+			almostEqualTime(t, crescent.NewMoon, time.Date(2024, 4, 8, 18, 21, 0, 0, time.UTC), time.Minute)
+			if d := crescent.Age - test.age; d < -2*time.Minute || d > 2*time.Minute {
+				t.Errorf("got age %v, want %v", crescent.Age, test.age)
+			}
+			if d := crescent.Lag - test.lag; d < -2*time.Minute || d > 2*time.Minute {
+				t.Errorf("got lag %v, want %v", crescent.Lag, test.lag)
+			}
+			if crescent.Yallop != test.yallop || crescent.Odeh != test.odeh || crescent.Visible() != test.visible {
+				t.Errorf("got %v, %v, want %v, %v", crescent.Yallop, crescent.Odeh, test.yallop, test.odeh)
+			}
+			// the moon is close to the sun, the arc of light is mostly the arc of vision
+			if arcv := math.Abs(crescent.ARCV); crescent.ARCL < arcv || crescent.ARCL > arcv+3 {
+				t.Errorf("got ARCL %v and ARCV %v", crescent.ARCL, crescent.ARCV)
+			}
+		})
+	}
+}
+
+func TestCrescents(t *testing.T) {
+	mecca := Observer{Latitude: 21.4225, Longitude: 39.8262}
+	loc := time.FixedZone("AST", 3*3600)
+
+	crescents := Crescents(mecca, time.Date(2024, 1, 1, 0, 0, 0, 0, loc), time.Date(2024, 4, 1, 0, 0, 0, 0, loc), loc)
+	if len(crescents) != 9 {
+		t.Fatalf("got %d evenings", len(crescents))
+	}
+	for i, crescent := range crescents {
+		// the crescent grows on three evenings after each new moon
+		if i%3 > 0 && crescent.Q <= crescents[i-1].Q {
+			t.Errorf("%v: q %v after %v", crescent.Sunset, crescent.Q, crescents[i-1].Q)
+		}
+		if i%3 == 2 && !crescent.Visible() {
+			t.Errorf("%v: crescent of %v not visible", crescent.Sunset, crescent.Age)
+		}
+	}
+}
+
+func TestCrescentMap(t *testing.T) {
+	// the crescent of Ramadan 1445 was seen in the Americas on 2024-03-10 but not in the
+	// Old World
+	points, err := CrescentMap(time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC), 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(points) != 6*12 {
+		t.Fatalf("got %d points", len(points))
+	}
+	for _, point := range points {
+		if point.Latitude < -30 || point.Latitude > 60 {
+			continue
+		}
+		visible := point.Yallop <= YallopC
+		if point.Longitude > -30 && visible {
+			t.Errorf("visible at %v, %v", point.Latitude, point.Longitude)
+		}
+		if point.Longitude == -165 && point.Latitude > 0 && !visible {
+			t.Errorf("not visible at %v, %v", point.Latitude, point.Longitude)
+		}
+	}
+	for _, step := range []float64{0, -10, math.NaN()} {
+		if _, err := CrescentMap(time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC), step); err == nil {
+			t.Errorf("step %v: no error", step)
+		}
+	}
+}
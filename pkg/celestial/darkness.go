@@ -0,0 +1,94 @@
+package celestial
+
+import (
+	"time"
+)
+
+// DarkWindow is an interval of truly dark sky, free of twilight and moonlight.
+type DarkWindow struct {
+	Start time.Time
+	End   time.Time
+	// MoonIllumination is the illuminated fraction of the moon in the middle of the window,
+	// which may be up if it is below the threshold
+	MoonIllumination float64
+}
+
+// Calculate the astronomical darkness of the night that starts on the evening of a day
+// Returns:
+//
+//	The start and the end of the darkness, false if the sun does not get lower than
+//	the astronomical twilight.
+func astronomical_night(observer Observer, day time.Time) (time.Time, time.Time, bool) {
+	dusk, err := Dusk(observer, day, DepressionAstronomical)
+	if err == nil {
+		dawn, err := Dawn(observer, day.AddDate(0, 0, 1), DepressionAstronomical)
+		if err == nil {
+			return dusk, dawn, true
+		}
+	}
+	// in the polar night the darkness lasts from noon to noon
+	noon := Noon(observer, day)
+	if Elevation(observer, noon, false) < -DepressionAstronomical {
+		return noon, Noon(observer, day.AddDate(0, 0, 1)), true
+	}
+	return time.Time{}, time.Time{}, false
+}
+
+// DarkWindows finds the times when the sky is truly dark for an observer: the sun is below
+// the astronomical twilight and the moon is below the horizon or only a thin crescent.
+// Args:
+//
+//	observer:        Observer to calculate for
+//	from, to:        The time range to search, the nights are taken in the timezone of from
+//	maxIllumination: The illuminated fraction from 0 to 1 up to which the moon may be above
+//	                 the horizon, 0 to require that the moon has set
+//
+// Returns:
+//
+//	The dark windows in the range in the timezone of from, clipped to the range.
+func DarkWindows(observer Observer, from, to time.Time, maxIllumination float64) []DarkWindow {
+	var windows []DarkWindow
+	add := func(start, end time.Time) {
+		if !start.Before(end) {
+			return
+		}
+		// windows of consecutive polar nights touch at noon
+		if n := len(windows); n > 0 && !windows[n-1].End.Before(start) {
+			windows[n-1].End = end.In(from.Location())
+			return
+		}
+		windows = append(windows, DarkWindow{
+			Start:            start.In(from.Location()),
+			End:              end.In(from.Location()),
+			MoonIllumination: MoonIllumination(start.Add(end.Sub(start) / 2)),
+		})
+	}
+
+	first := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location()).AddDate(0, 0, -1)
+	for day := first; day.Before(to); day = day.AddDate(0, 0, 1) {
+		start, end, ok := astronomical_night(observer, day)
+		if !ok {
+			continue
+		}
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(to) {
+			end = to
+		}
+		if !start.Before(end) {
+			continue
+		}
+
+		// cut out the moonlit intervals
+		darkStart := start
+		for _, moonUp := range body_above(Moon, observer, start, end) {
+			if MoonIllumination(moonUp.start.Add(moonUp.end.Sub(moonUp.start)/2)) > maxIllumination {
+				add(darkStart, moonUp.start)
+				darkStart = moonUp.end
+			}
+		}
+		add(darkStart, end)
+	}
+	return windows
+}
@@ -0,0 +1,64 @@
+package celestial
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDarkWindows(t *testing.T) {
+	berlin := Observer{Latitude: 52.52, Longitude: 13.40}
+	loc, _ := time.LoadLocation("Europe/Berlin")
+	from := time.Date(2024, 10, 1, 12, 0, 0, 0, loc)
+	to := from.AddDate(0, 0, 31)
+	windows := DarkWindows(berlin, from, to, 0.1)
+	if len(windows) < 20 {
+		t.Fatalf("got %d windows", len(windows))
+	}
+
+	for i, window := range windows {
+		if !window.Start.Before(window.End) || window.Start.Before(from) || window.End.After(to) {
+			t.Fatalf("window %v to %v", window.Start, window.End)
+		}
+		if i > 0 && !windows[i-1].End.Before(window.Start) {
+			t.Fatalf("windows %d and %d overlap", i-1, i)
+		}
+		for dateandtime := window.Start.Add(time.Minute); dateandtime.Before(window.End); dateandtime = dateandtime.Add(20 * time.Minute) {
+			if elevation := Elevation(berlin, dateandtime, false); elevation > -DepressionAstronomical+0.01 {
+				t.Fatalf("%v: sun at %f", dateandtime, elevation)
+			}
+			if moon, _ := body_horizontal(Moon, berlin, dateandtime); moon > StandardAltitudeMoon && MoonIllumination(dateandtime) > 0.1+0.01 {
+				t.Fatalf("%v: moon up with %f", dateandtime, MoonIllumination(dateandtime))
+			}
+		}
+	}
+
+	// the night after the new moon of October 2nd is dark from dusk to dawn
+	dusk, _ := Dusk(berlin, time.Date(2024, 10, 2, 0, 0, 0, 0, loc), DepressionAstronomical)
+	dawn, _ := Dawn(berlin, time.Date(2024, 10, 3, 0, 0, 0, 0, loc), DepressionAstronomical)
+	almostEqualTime(t, windows[1].Start, dusk, time.Second)
+	almostEqualTime(t, windows[1].End, dawn, time.Second)
+
+	// the full moon of October 17th lights up the whole night
+	fullMoon := time.Date(2024, 10, 17, 1, 0, 0, 0, loc)
+	for _, window := range windows {
+		if !window.Start.After(fullMoon) && window.End.After(fullMoon) {
+			t.Fatalf("dark at full moon from %v to %v", window.Start, window.End)
+		}
+	}
+}
+
+func TestDarkWindowsPolarNight(t *testing.T) {
+	// the sun stays below the astronomical twilight around the winter solstice near the pole,
+	// so that the nights merge into one window when the moon does not matter
+	from := time.Date(2024, 12, 18, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+	windows := DarkWindows(Observer{Latitude: 85, Longitude: 0}, from, to, 1)
+	if len(windows) != 1 || !windows[0].Start.Equal(from) || !windows[0].End.Equal(to) {
+		t.Fatalf("windows %v", windows)
+	}
+
+	// no astronomical darkness at midsummer in the north
+	if windows := DarkWindows(Observer{Latitude: 55, Longitude: 0}, time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC), time.Date(2024, 6, 25, 0, 0, 0, 0, time.UTC), 1); len(windows) != 0 {
+		t.Fatalf("windows %v", windows)
+	}
+}
@@ -0,0 +1,328 @@
+package celestial
+
+import (
+	"math"
+	"time"
+)
+
+// WGS84 ellipsoid parameters
+const (
+	wgs84SemiMajorAxis = 6378137.0
+	wgs84Flattening    = 1 / 298.257223563
+	wgs84SemiMinorAxis = wgs84SemiMajorAxis * (1 - wgs84Flattening)
+)
+
+// Kaaba is the location of the Kaaba in Mecca, the direction of the Qibla.
+var Kaaba = Observer{Latitude: 21.422487, Longitude: 39.826206, Elevation: 277}
+
+// Solve the inverse geodesic problem on the WGS84 ellipsoid using Vincenty's formulae.
+//
+// See https://en.wikipedia.org/wiki/Vincenty%27s_formulae
+// Args:
+//
+//	lat1, long1: The latitude and longitude of the first point in degrees
+//	lat2, long2: The latitude and longitude of the second point in degrees
+//
+// Returns:
+//
+//	The distance in metres and the initial and final bearings in degrees clockwise from North.
+//	Nearly antipodal points, for which the iteration does not converge, are solved
+//	by vincenty_antipodal instead.
+func vincenty_inverse(lat1, long1, lat2, long2 float64) (float64, float64, float64) {
+	f := wgs84Flattening
+
+	L := radians(long2 - long1)
+	U1 := math.Atan((1 - f) * math.Tan(radians(lat1)))
+	U2 := math.Atan((1 - f) * math.Tan(radians(lat2)))
+	sinU1, cosU1 := math.Sincos(U1)
+	sinU2, cosU2 := math.Sincos(U2)
+
+	lambda := L
+	var sinSigma, cosSigma, sigma, cos2Alpha, cos2SigmaM, sinLambda, cosLambda float64
+	converged := false
+	for i := 0; i < 200; i++ {
+		sinLambda, cosLambda = math.Sincos(lambda)
+		sinSigma = math.Hypot(cosU2*sinLambda, cosU1*sinU2-sinU1*cosU2*cosLambda)
+		if sinSigma == 0 {
+			// coincident points
+			return 0, 0, 0
+		}
+		cosSigma = sinU1*sinU2 + cosU1*cosU2*cosLambda
+		sigma = math.Atan2(sinSigma, cosSigma)
+		sinAlpha := cosU1 * cosU2 * sinLambda / sinSigma
+		cos2Alpha = 1 - sinAlpha*sinAlpha
+		cos2SigmaM = 0.0
+		if cos2Alpha != 0 {
+			// not on the equatorial line
+			cos2SigmaM = cosSigma - 2*sinU1*sinU2/cos2Alpha
+		}
+		C := f / 16 * cos2Alpha * (4 + f*(4-3*cos2Alpha))
+		previous := lambda
+		lambda = L + (1-C)*f*sinAlpha*(sigma+C*sinSigma*(cos2SigmaM+C*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+		if math.Abs(lambda-previous) < 1e-12 {
+			converged = true
+			break
+		}
+	}
+
+	if !converged {
+		return vincenty_antipodal(lat1, long1, lat2, long2)
+	}
+
+	distance := vincenty_distance(cos2Alpha, sigma, sinSigma, cosSigma, cos2SigmaM)
+	initial := degrees(math.Atan2(cosU2*sinLambda, cosU1*sinU2-sinU1*cosU2*cosLambda))
+	final := degrees(math.Atan2(cosU1*sinLambda, -sinU1*cosU2+cosU1*sinU2*cosLambda))
+
+	return distance, properAngle(initial), properAngle(final)
+}
+
+// Calculate the length of a geodesic from its arc length on the auxiliary sphere
+func vincenty_distance(cos2Alpha, sigma, sinSigma, cosSigma, cos2SigmaM float64) float64 {
+	a := wgs84SemiMajorAxis
+	b := wgs84SemiMinorAxis
+
+	uSq := cos2Alpha * (a*a - b*b) / (b * b)
+	A := 1 + uSq/16384*(4096+uSq*(-768+uSq*(320-175*uSq)))
+	B := uSq / 1024 * (256 + uSq*(-128+uSq*(74-47*uSq)))
+	deltaSigma := B * sinSigma * (cos2SigmaM + B/4*(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
+		B/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+
+	return b * A * (sigma - deltaSigma)
+}
+
+// Solve the inverse geodesic problem for nearly antipodal points, where the iteration
+// on the longitude in vincenty_inverse does not converge. The initial azimuth is found
+// by bisection instead, following the setup of Karney, Algorithms for geodesics (2013):
+// with the points arranged so that lat1 <= 0, |lat1| >= |lat2| and the second point lies
+// east of the first, the longitude at which a geodesic reaches the latitude of the second
+// point heading north increases monotonically with the initial azimuth from 0 to 180 degrees.
+//
+// See https://doi.org/10.1007/s00190-012-0578-z
+// Args:
+//
+//	lat1, long1: The latitude and longitude of the first point in degrees
+//	lat2, long2: The latitude and longitude of the second point in degrees
+//
+// Returns:
+//
+//	The distance in metres and the initial and final bearings in degrees clockwise from North.
+func vincenty_antipodal(lat1, long1, lat2, long2 float64) (float64, float64, float64) {
+	f := wgs84Flattening
+
+	swapped := math.Abs(lat1) < math.Abs(lat2)
+	if swapped {
+		lat1, long1, lat2, long2 = lat2, long2, lat1, long1
+	}
+	mirrored := lat1 > 0
+	if mirrored {
+		lat1, lat2 = -lat1, -lat2
+	}
+	L := radians(angle_difference(long2, long1))
+	westward := L < 0
+	if westward {
+		L = -L
+	}
+
+	sinU1, cosU1 := math.Sincos(math.Atan((1 - f) * math.Tan(radians(lat1))))
+	sinU2, cosU2 := math.Sincos(math.Atan((1 - f) * math.Tan(radians(lat2))))
+
+	var sinAlpha, cos2Alpha, cosAlpha2, sigma, sinSigma, cosSigma, cos2SigmaM float64
+	alpha1 := 0.0
+	lo, hi := 0.0, math.Pi
+	for i := 0; i < 64; i++ {
+		alpha1 = (lo + hi) / 2
+		sinAlpha1, cosAlpha1 := math.Sincos(alpha1)
+		sinAlpha = sinAlpha1 * cosU1
+		cos2Alpha = 1 - sinAlpha*sinAlpha
+		// cos(alpha2) cos(U2) on the way north, real as long as |lat2| <= |lat1|
+		cosAlpha2 = math.Sqrt(math.Max(cosAlpha1*cosAlpha1*cosU1*cosU1+cosU2*cosU2-cosU1*cosU1, 0))
+
+		sigma1 := math.Atan2(sinU1, cosAlpha1*cosU1)
+		sigma2 := math.Atan2(sinU2, cosAlpha2)
+		omega1 := math.Atan2(sinAlpha*sinU1, cosAlpha1*cosU1)
+		omega2 := math.Atan2(sinAlpha*sinU2, cosAlpha2)
+		sigma = math.Mod(sigma2-sigma1+2*math.Pi, 2*math.Pi)
+		omega := math.Mod(omega2-omega1+2*math.Pi, 2*math.Pi)
+
+		sinSigma, cosSigma = math.Sincos(sigma)
+		cos2SigmaM = math.Cos(sigma1 + sigma2)
+		C := f / 16 * cos2Alpha * (4 + f*(4-3*cos2Alpha))
+		lambda := omega - (1-C)*f*sinAlpha*(sigma+C*sinSigma*(cos2SigmaM+C*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+
+		if lambda < L {
+			lo = alpha1
+		} else {
+			hi = alpha1
+		}
+	}
+
+	distance := vincenty_distance(cos2Alpha, sigma, sinSigma, cosSigma, cos2SigmaM)
+	initial := degrees(alpha1)
+	final := degrees(math.Atan2(sinAlpha, cosAlpha2))
+
+	// undo the arrangement of the points
+	if westward {
+		initial, final = -initial, -final
+	}
+	if mirrored {
+		initial, final = 180-initial, 180-final
+	}
+	if swapped {
+		initial, final = final+180, initial+180
+	}
+
+	return distance, properAngle(initial), properAngle(final)
+}
+
+func clamp(value, min, max float64) float64 {
+	if value < min {
+		return min
+	}
+	if value > max {
+		return max
+	}
+	return value
+}
+
+// Bearing calculates the initial bearing of the geodesic from the observer to another point.
+// Returns:
+//
+//	The bearing in degrees clockwise from North.
+func (o Observer) Bearing(to Observer) float64 {
+	_, initial, _ := vincenty_inverse(o.Latitude, o.Longitude, to.Latitude, to.Longitude)
+	return initial
+}
+
+// Distance calculates the length of the geodesic from the observer to another point
+// on the WGS84 ellipsoid. The elevation of either point is ignored.
+// Returns:
+//
+//	The distance in metres.
+func (o Observer) Distance(to Observer) float64 {
+	distance, _, _ := vincenty_inverse(o.Latitude, o.Longitude, to.Latitude, to.Longitude)
+	return distance
+}
+
+// Coefficient of terrestrial refraction used for lines of sight close to the ground
+const terrestrialRefraction = 0.13
+
+// ElevationAngle calculates the apparent elevation of another point as seen by the observer.
+// The drop of the earth's surface and terrestrial refraction along the line of sight
+// are taken into account, both observer and point use their Elevation as height in metres.
+// Returns:
+//
+//	The elevation angle in degrees above the horizon.
+func (o Observer) ElevationAngle(to Observer) float64 {
+	const r = (2*wgs84SemiMajorAxis + wgs84SemiMinorAxis) / 3
+
+	d := o.Distance(to)
+	if d == 0 {
+		if to.Elevation > o.Elevation {
+			return 90
+		} else if to.Elevation < o.Elevation {
+			return -90
+		}
+		return 0
+	}
+	return degrees(math.Atan((to.Elevation-o.Elevation)/d - d*(1-terrestrialRefraction)/(2*r)))
+}
+
+// Qibla calculates the direction of the Kaaba from the observer.
+// Returns:
+//
+//	The bearing in degrees clockwise from North.
+func (o Observer) Qibla() float64 {
+	return o.Bearing(Kaaba)
+}
+
+// Normalise an angle difference to the range (-180, 180]
+func angle_difference(a, b float64) float64 {
+	d := math.Mod(a-b, 360)
+	if d > 180 {
+		d -= 360
+	} else if d <= -180 {
+		d += 360
+	}
+	return d
+}
+
+// Find all times between start and end when the sun's azimuth equals the given azimuth.
+// The search samples the azimuth every few minutes and bisects every crossing to
+// the nearest second.
+func sun_azimuth_crossings(observer Observer, azimuth float64, start, end time.Time) []time.Time {
+	const step = 5 * time.Minute
+
+	diffAt := func(t time.Time) float64 {
+		_, az := ZenithAndAzimuth(observer, t, true)
+		return angle_difference(az, azimuth)
+	}
+
+	var crossings []time.Time
+	t0 := start
+	d0 := diffAt(t0)
+	for t0.Before(end) {
+		t1 := t0.Add(step)
+		if t1.After(end) {
+			t1 = end
+		}
+		d1 := diffAt(t1)
+
+		// a jump across ±180 degrees is the opposite direction, not a crossing
+		if d0 == 0 {
+			crossings = append(crossings, t0)
+		} else if d0*d1 < 0 && math.Abs(d0-d1) < 180 {
+			lo, hi, dlo := t0, t1, d0
+			for hi.Sub(lo) > time.Second {
+				mid := lo.Add(hi.Sub(lo) / 2)
+				dmid := diffAt(mid)
+				if dlo*dmid <= 0 {
+					hi = mid
+				} else {
+					lo, dlo = mid, dmid
+				}
+			}
+			crossings = append(crossings, lo.Round(time.Second))
+		}
+		t0, d0 = t1, d1
+	}
+	return crossings
+}
+
+// TimesAtAzimuth calculates the times on the specified date when the sun is
+// above the horizon at the specified azimuth.
+// Args:
+//
+//	observer: Observer to calculate for
+//	azimuth:  Azimuth in degrees clockwise from North
+//	date:     Date to calculate for. The day is taken in the date's timezone.
+//
+// Returns:
+//
+//	The times in the date's timezone at which the sun's azimuth equals the given azimuth.
+//	The slice is empty if the sun does not pass that azimuth while it is up.
+func TimesAtAzimuth(observer Observer, azimuth float64, date time.Time) []time.Time {
+	start := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	end := start.AddDate(0, 0, 1)
+
+	var times []time.Time
+	for _, t := range sun_azimuth_crossings(observer, azimuth, start, end) {
+		if Elevation(observer, t, true) > 0 {
+			times = append(times, t.In(date.Location()))
+		}
+	}
+	return times
+}
+
+// QiblaSunTimes calculates the times on the specified date when the sun stands
+// in the direction of the Qibla, so that it can be used as a compass reference.
+// Args:
+//
+//	observer: Observer to calculate for
+//	date:     Date to calculate for. The day is taken in the date's timezone.
+//
+// Returns:
+//
+//	The times in the date's timezone at which the sun's azimuth equals the Qibla bearing.
+func QiblaSunTimes(observer Observer, date time.Time) []time.Time {
+	return TimesAtAzimuth(observer, observer.Qibla(), date)
+}
@@ -0,0 +1,165 @@
+package celestial
+
+import (
+	"testing"
+	"time"
+)
+
+var flindersPeak = Observer{Latitude: -37.951033416666665, Longitude: 144.42486788888888}
+var buninyong = Observer{Latitude: -37.65282114722222, Longitude: 143.92649552777777}
+var newYork = Observer{Latitude: 40.7128, Longitude: -74.0060}
+
+func TestDistance(t *testing.T) {
+	type args struct {
+		from Observer
+		to   Observer
+	}
+	tests := []struct {
+		name      string
+		args      args
+		want      float64
+		tolerance float64
+	}{
+		{args: args{from: flindersPeak, to: buninyong}, want: 54972.271, tolerance: 0.001},
+		{args: args{from: london, to: london}, want: 0, tolerance: 0.001},
+		{args: args{from: Observer{Latitude: 0, Longitude: 0}, to: Observer{Latitude: 0, Longitude: 1}}, want: 111319.491, tolerance: 0.001},
+		// slow convergence, see https://en.wikipedia.org/wiki/Vincenty%27s_formulae#Nearly_antipodal_points
+		{args: args{from: Observer{Latitude: 0, Longitude: 0}, to: Observer{Latitude: 0.5, Longitude: 179.5}}, want: 19936288.579, tolerance: 0.001},
+		// no convergence, checked by numerical integration of the geodesic
+		{args: args{from: Observer{Latitude: 0, Longitude: 0}, to: Observer{Latitude: 0.5, Longitude: 179.7}}, want: 19944127.421, tolerance: 0.001},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.args.from.Distance(tt.args.to)
+			almostEqualFloat(t, got, tt.want, tt.tolerance)
+		})
+	}
+}
+
+func TestVincentyAntipodal(t *testing.T) {
+	tests := []struct {
+		name                     string
+		lat1, long1, lat2, long2 float64
+		want                     float64
+		wantInitial              float64
+		wantFinal                float64
+	}{
+		// Vincenty's test line, which also converges in vincenty_inverse
+		{lat1: flindersPeak.Latitude, long1: flindersPeak.Longitude, lat2: buninyong.Latitude, long2: buninyong.Longitude, want: 54972.271, wantInitial: 306.86815920, wantFinal: 307.17363104},
+		{lat1: 0, long1: 0, lat2: 0.5, long2: 179.5, want: 19936288.579, wantInitial: 25.67187285, wantFinal: 154.32708549},
+		// both points on the equator, checked by numerical integration of the geodesic
+		{lat1: 0, long1: 0, lat2: 0, long2: 179.5, want: 19980861.909, wantInitial: 124.03350500, wantFinal: 55.96649500},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, initial, final := vincenty_antipodal(tt.lat1, tt.long1, tt.lat2, tt.long2)
+			almostEqualFloat(t, got, tt.want, 0.001)
+			almostEqualFloat(t, initial, tt.wantInitial, 0.00001)
+			almostEqualFloat(t, final, tt.wantFinal, 0.00001)
+		})
+	}
+}
+
+func TestBearing(t *testing.T) {
+	type args struct {
+		from Observer
+		to   Observer
+	}
+	tests := []struct {
+		name string
+		args args
+		want float64
+	}{
+		{args: args{from: flindersPeak, to: buninyong}, want: 306.86815920},
+		{args: args{from: buninyong, to: flindersPeak}, want: 127.17363103},
+		{args: args{from: Observer{Latitude: 0, Longitude: 0}, to: Observer{Latitude: 10, Longitude: 0}}, want: 0},
+		{args: args{from: Observer{Latitude: 0, Longitude: 0}, to: Observer{Latitude: 0, Longitude: -10}}, want: 270},
+		{args: args{from: Observer{Latitude: 0, Longitude: 0}, to: Observer{Latitude: 0.5, Longitude: 179.7}}, want: 15.55688279},
+		{args: args{from: Observer{Latitude: 0.5, Longitude: 179.7}, to: Observer{Latitude: 0, Longitude: 0}}, want: 344.44251390},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.args.from.Bearing(tt.args.to)
+			almostEqualFloat(t, got, tt.want, 0.00001)
+		})
+	}
+}
+
+func TestQibla(t *testing.T) {
+	tests := []struct {
+		name     string
+		observer Observer
+		want     float64
+	}{
+		{observer: london, want: 118.99},
+		{observer: newYork, want: 58.48},
+		{observer: newDelhi, want: 266.6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.observer.Qibla()
+			almostEqualFloat(t, got, tt.want, 0.2)
+		})
+	}
+}
+
+func TestTimesAtAzimuth(t *testing.T) {
+	type args struct {
+		observer Observer
+		azimuth  float64
+		date     time.Time
+	}
+	tests := []struct {
+		name string
+		args args
+		want []time.Time
+	}{
+		{args: args{observer: london, azimuth: 166.9676, date: time.Date(2015, 12, 14, 0, 0, 0, 0, time.UTC)}, want: []time.Time{time.Date(2015, 12, 14, 11, 0, 0, 0, time.UTC)}},
+		// the sun is below the horizon in the north
+		{args: args{observer: london, azimuth: 0, date: time.Date(2015, 12, 14, 0, 0, 0, 0, time.UTC)}, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TimesAtAzimuth(tt.args.observer, tt.args.azimuth, tt.args.date)
+			if len(got) != len(tt.want) {
+				t.Fatalf("TimesAtAzimuth() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				almostEqualTime(t, got[i], tt.want[i], 60*time.Second)
+			}
+		})
+	}
+}
+
+func TestQiblaSunTimes(t *testing.T) {
+	date := time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC)
+	got := QiblaSunTimes(london, date)
+	if len(got) != 1 {
+		t.Fatalf("QiblaSunTimes() = %v, want a single time", got)
+	}
+	almostEqualFloat(t, Azimuth(london, got[0]), london.Qibla(), 0.01)
+}
+
+func TestElevationAngle(t *testing.T) {
+	type args struct {
+		from Observer
+		to   Observer
+	}
+	tests := []struct {
+		name string
+		args args
+		want float64
+	}{
+		{args: args{from: london, to: london}, want: 0},
+		{args: args{from: london, to: Observer{Latitude: london.Latitude, Longitude: london.Longitude, Elevation: 10}}, want: 90},
+		{args: args{from: london, to: Observer{Latitude: london.Latitude + 0.01, Longitude: london.Longitude, Elevation: 1112}}, want: 44.98},
+		// a distant point at the same height lies below the horizon
+		{args: args{from: Observer{Latitude: 0, Longitude: 0}, to: Observer{Latitude: 0, Longitude: 1}}, want: -0.4365},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.args.from.ElevationAngle(tt.args.to)
+			almostEqualFloat(t, got, tt.want, 0.01)
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package celestial
+
+import (
+	"time"
+
+	"github.com/interimme/celestial/pkg/geojson"
+)
+
+// GroundPoint is the point on the earth below a body at a moment in time.
+type GroundPoint struct {
+	Time      time.Time
+	Latitude  float64
+	Longitude float64
+}
+
+// SubsolarPoint calculates the point on the earth where the sun stands in the zenith.
+// Args:
+//
+//	dateandtime: The date and time to calculate for
+//
+// Returns:
+//
+//	The latitude and longitude in degrees, the longitude in the range (-180, 180].
+func SubsolarPoint(dateandtime time.Time) (float64, float64) {
+	return subsolar_point(dateandtime)
+}
+
+// Calculate the point on the earth where the moon stands in the zenith
+//
+// The moon is close enough for its parallax to move it by up to a degree from the
+// geocentric position, so the point is refined until the topocentric position of the
+// moon lies along the vertical of the WGS84 ellipsoid.
+func sublunar_point(dateandtime time.Time) (float64, float64) {
+	ra, dec, distance := moon_equatorial(dateandtime)
+	gast := greenwich_apparent_sidereal_time(julian_datetime(dateandtime))
+
+	// the zenith has a declination of the geodetic latitude and a right ascension of the local sidereal time
+	latitude, longitude := dec, angle_difference(ra-gast, 0)
+	for i := 0; i < 5; i++ {
+		lst := gast + longitude
+		topoRA, topoDec, _ := equatorial_to_topocentric(Observer{Latitude: latitude, Longitude: longitude}, lst, ra, dec, distance)
+		latitude = topoDec
+		longitude = angle_difference(longitude+angle_difference(topoRA, lst), 0)
+	}
+	return latitude, longitude
+}
+
+// SublunarPoint calculates the point on the earth where the moon stands in the zenith.
+// Args:
+//
+//	dateandtime: The date and time to calculate for
+//
+// Returns:
+//
+//	The latitude and longitude in degrees, the longitude in the range (-180, 180].
+func SublunarPoint(dateandtime time.Time) (float64, float64) {
+	return sublunar_point(dateandtime)
+}
+
+func ground_track(point func(time.Time) (float64, float64), from, to time.Time, step time.Duration) []GroundPoint {
+	if step <= 0 {
+		return nil
+	}
+
+	var track []GroundPoint
+	for t := from; !t.After(to); t = t.Add(step) {
+		latitude, longitude := point(t)
+		track = append(track, GroundPoint{Time: t, Latitude: latitude, Longitude: longitude})
+	}
+	return track
+}
+
+// SubsolarTrack calculates the path of the subsolar point over a time range.
+// Args:
+//
+//	from, to: The time range to calculate for, both ends included
+//	step:     The time between two points of the track
+//
+// Returns:
+//
+//	The points of the track, empty if the step is not positive.
+func SubsolarTrack(from, to time.Time, step time.Duration) []GroundPoint {
+	return ground_track(subsolar_point, from, to, step)
+}
+
+// SublunarTrack calculates the path of the sublunar point over a time range.
+// Args:
+//
+//	from, to: The time range to calculate for, both ends included
+//	step:     The time between two points of the track
+//
+// Returns:
+//
+//	The points of the track, empty if the step is not positive.
+func SublunarTrack(from, to time.Time, step time.Duration) []GroundPoint {
+	return ground_track(sublunar_point, from, to, step)
+}
+
+// GroundTrackFeature converts a ground track to a GeoJSON MultiLineString feature.
+// The track is split where it crosses the antimeridian, with the crossing interpolated
+// onto both sides, so that it draws correctly on a flat map.
+// Args:
+//
+//	track:      The points of the track
+//	properties: The properties of the feature, may be nil
+func GroundTrackFeature(track []GroundPoint, properties map[string]interface{}) geojson.Feature {
+	var lines [][]geojson.Position
+	var line []geojson.Position
+	for i, p := range track {
+		if i > 0 {
+			previous := track[i-1]
+			delta := angle_difference(p.Longitude, previous.Longitude)
+			if unwrapped := previous.Longitude + delta; unwrapped > 180 || unwrapped <= -180 {
+				edge := 180.0
+				if delta < 0 {
+					edge = -180
+				}
+				f := (edge - previous.Longitude) / delta
+				latitude := previous.Latitude + f*(p.Latitude-previous.Latitude)
+				line = append(line, geojson.Position{edge, latitude})
+				lines = append(lines, line)
+				line = []geojson.Position{{-edge, latitude}}
+			}
+		}
+		line = append(line, geojson.Position{p.Longitude, p.Latitude})
+	}
+	if len(line) > 1 {
+		lines = append(lines, line)
+	}
+	return geojson.NewFeature(geojson.NewMultiLineString(lines), properties)
+}
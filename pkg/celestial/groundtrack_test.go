@@ -0,0 +1,75 @@
+package celestial
+
+import (
+	"testing"
+	"time"
+
+	"github.com/interimme/celestial/pkg/geojson"
+)
+
+func TestSublunarPoint(t *testing.T) {
+	var tests = []time.Time{
+		time.Date(1992, 4, 12, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 4, 8, 18, 18, 0, 0, time.UTC),
+		time.Date(2024, 12, 15, 6, 0, 0, 0, time.UTC),
+	}
+
+	for _, dateandtime := range tests {
+		t.Run(dateandtime.String(), func(t *testing.T) {
+			latitude, longitude := SublunarPoint(dateandtime)
+			zenith, _ := MoonZenithAndAzimuth(Observer{latitude, longitude, 0}, dateandtime, false)
+			almostEqualFloat(t, zenith, 0, 0.001)
+
+			// the parallax moves the point away from the geocentric declination
+			_, dec, _ := moon_equatorial(dateandtime)
+			almostEqualFloat(t, latitude, dec, 1.2)
+		})
+	}
+}
+
+func TestSublunarPointEclipse(t *testing.T) {
+	// the greatest eclipse of April 8th 2024 at 25.3N 104.1W, where sun and moon stand
+	// close together high in the sky
+	dateandtime := time.Date(2024, 4, 8, 18, 17, 16, 0, time.UTC)
+	sunLatitude, sunLongitude := SubsolarPoint(dateandtime)
+	moonLatitude, moonLongitude := SublunarPoint(dateandtime)
+	almostEqualFloat(t, sunLatitude, 7.6, 0.1)
+	almostEqualFloat(t, sunLongitude, -93.9, 0.1)
+
+	distance := Observer{Latitude: sunLatitude, Longitude: sunLongitude}.Distance(Observer{Latitude: moonLatitude, Longitude: moonLongitude})
+	if distance > 3000e3 {
+		t.Errorf("subsolar and sublunar points are %.0f km apart", distance/1000)
+	}
+}
+
+func TestGroundTrack(t *testing.T) {
+	from := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(48 * time.Hour)
+
+	track := SubsolarTrack(from, to, time.Hour)
+	if len(track) != 49 {
+		t.Fatalf("got %d points, want 49", len(track))
+	}
+	for _, p := range track {
+		latitude, longitude := SubsolarPoint(p.Time)
+		almostEqualFloat(t, p.Latitude, latitude, 0)
+		almostEqualFloat(t, p.Longitude, longitude, 0)
+	}
+	if len(SublunarTrack(from, to, 0)) != 0 {
+		t.Errorf("expected no points for a zero step")
+	}
+
+	// the subsolar point circles the earth westward once a day and crosses the antimeridian twice in two days
+	feature := GroundTrackFeature(track, nil)
+	lines := feature.Geometry.Coordinates.([][]geojson.Position)
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want 3", len(lines))
+	}
+	for i := 1; i < len(lines); i++ {
+		end := lines[i-1][len(lines[i-1])-1]
+		start := lines[i][0]
+		almostEqualFloat(t, end[0], -180, 0)
+		almostEqualFloat(t, start[0], 180, 0)
+		almostEqualFloat(t, end[1], start[1], 0)
+	}
+}
@@ -35,3 +35,72 @@ func jday_to_jcentury(julianday float64) float64 {
 func jcentury_to_jday(juliancentury float64) float64 {
 	return (juliancentury * 36525.0) + 2451545.0
 }
+
+// Calculate the Julian Day for the specified date and time, including the
+// fraction of the day elapsed since midnight UTC
+func julian_datetime(dateandtime time.Time) float64 {
+	utc := dateandtime.UTC()
+	midnight := time.Date(utc.Year(), utc.Month(), utc.Day(), 0, 0, 0, 0, time.UTC)
+	return julianday(utc) + utc.Sub(midnight).Hours()/24.0
+}
+
+// Convert a Julian Day number to the time.Time it designates, in UTC
+func jday_to_datetime(julianday float64) time.Time {
+	// 2440587.5 is the Julian Day of the Unix epoch
+	seconds := (julianday - 2440587.5) * 86400.0
+	whole := math.Floor(seconds)
+	return time.Unix(int64(whole), int64((seconds-whole)*1e9)).UTC().Round(time.Millisecond)
+}
+
+// Calculate the difference between Terrestrial Time and Universal Time (ΔT)
+//
+// See https://eclipse.gsfc.nasa.gov/SEhelp/deltatpoly2004.html
+// Args:
+//
+//	date: The date to calculate for
+//
+// Returns:
+//
+//	ΔT in seconds.
+func delta_t(date time.Time) float64 {
+	utc := date.UTC()
+	y := float64(utc.Year()) + (float64(utc.YearDay())-0.5)/365.25
+
+	switch {
+	case y < 1860:
+		u := (y - 1820) / 100
+		return -20 + 32*u*u
+	case y < 1900:
+		t := y - 1860
+		return 7.62 + 0.5737*t - 0.251754*t*t + 0.01680668*t*t*t - 0.0004473624*t*t*t*t + t*t*t*t*t/233174
+	case y < 1920:
+		t := y - 1900
+		return -2.79 + 1.494119*t - 0.0598939*t*t + 0.0061966*t*t*t - 0.000197*t*t*t*t
+	case y < 1941:
+		t := y - 1920
+		return 21.20 + 0.84493*t - 0.076100*t*t + 0.0020936*t*t*t
+	case y < 1961:
+		t := y - 1950
+		return 29.07 + 0.407*t - t*t/233 + t*t*t/2547
+	case y < 1986:
+		t := y - 1975
+		return 45.45 + 1.067*t - t*t/260 - t*t*t/718
+	case y < 2005:
+		t := y - 2000
+		return 63.86 + 0.3345*t - 0.060374*t*t + 0.0017275*t*t*t + 0.000651814*t*t*t*t + 0.00002373599*t*t*t*t*t
+	case y < 2050:
+		t := y - 2000
+		return 62.92 + 0.32217*t + 0.005589*t*t
+	case y < 2150:
+		u := (y - 1820) / 100
+		return -20 + 32*u*u - 0.5628*(2150-y)
+	default:
+		u := (y - 1820) / 100
+		return -20 + 32*u*u
+	}
+}
+
+// Calculate the Julian Ephemeris Day, on the Terrestrial Time scale, for the specified date and time
+func julian_ephemeris_day(dateandtime time.Time) float64 {
+	return julian_datetime(dateandtime) + delta_t(dateandtime)/86400.0
+}
@@ -90,3 +90,48 @@ func TestJulianCenturyToDay(t *testing.T) {
 		})
 	}
 }
+
+func TestJulianDatetime(t *testing.T) {
+	type args struct {
+		date time.Time
+	}
+	tests := []struct {
+		name string
+		args args
+		want float64
+	}{
+		{args: args{date: time.Date(1957, 10, 4, 19, 26, 24, 0, time.UTC)}, want: 2436116.31},
+		{args: args{date: time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC)}, want: 2451545.0},
+		{args: args{date: time.Date(2000, 1, 1, 13, 0, 0, 0, time.FixedZone("CET", 3600))}, want: 2451545.0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := julian_datetime(tt.args.date)
+			almostEqualFloat(t, got, tt.want, 0.00001)
+			almostEqualTime(t, jday_to_datetime(got), tt.args.date, time.Second)
+		})
+	}
+}
+
+func TestDeltaT(t *testing.T) {
+	type args struct {
+		date time.Time
+	}
+	tests := []struct {
+		name string
+		args args
+		want float64
+	}{
+		{args: args{date: time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC)}, want: -2.7},
+		{args: args{date: time.Date(1950, 1, 1, 0, 0, 0, 0, time.UTC)}, want: 29.1},
+		{args: args{date: time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)}, want: 56.9},
+		{args: args{date: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)}, want: 63.8},
+		{args: args{date: time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC)}, want: 66.7},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := delta_t(tt.args.date)
+			almostEqualFloat(t, got, tt.want, 1)
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package celestial
+
+import (
+	"math"
+	"time"
+)
+
+// The inclination of the mean lunar equator to the ecliptic in degrees
+const moonEquatorInclination = 1.54242
+
+// MoonPhysicalEphemeris describes the orientation of the moon seen from the centre of the
+// earth. All angles are in degrees.
+type MoonPhysicalEphemeris struct {
+	// OpticalLibrationLongitude and OpticalLibrationLatitude are the librations caused by
+	// the geometry of the orbit, which make up nearly all of the libration
+	OpticalLibrationLongitude float64
+	OpticalLibrationLatitude  float64
+	// PhysicalLibrationLongitude and PhysicalLibrationLatitude are the small librations of
+	// the moon's rotation itself
+	PhysicalLibrationLongitude float64
+	PhysicalLibrationLatitude  float64
+	// LibrationLongitude and LibrationLatitude are the total librations, the selenographic
+	// coordinates of the centre of the visible disk. A positive longitude turns Mare
+	// Crisium towards the observer, a positive latitude the north pole.
+	LibrationLongitude float64
+	LibrationLatitude  float64
+	// PositionAngle is the angle of the northern rotation axis, counted from the north
+	// towards the east on the sky
+	PositionAngle float64
+	// SubsolarLongitude and SubsolarLatitude are the selenographic coordinates of the point
+	// on the moon with the sun in its zenith
+	SubsolarLongitude float64
+	SubsolarLatitude  float64
+	// Colongitude is the selenographic longitude of the morning terminator, where the sun
+	// rises: about 0 at first quarter, 90 at full moon, 180 at last quarter and 270 at new moon
+	Colongitude float64
+}
+
+// Calculate the selenographic coordinates of the point on the moon that faces a direction
+// given by its ecliptic longitude and latitude
+//
+// See Meeus, Astronomical Algorithms, chapter 53
+// Args:
+//
+//	juliancentury: The Julian Century on the TT scale
+//	longitude:     The ecliptic longitude in degrees referred to the mean equinox of date
+//	latitude:      The ecliptic latitude in degrees
+//
+// Returns:
+//
+//	The optical libration in longitude and latitude and the physical libration in
+//	longitude and latitude.
+func moon_libration(juliancentury, longitude, latitude float64) (float64, float64, float64, float64) {
+	_, _, _, _, f := moon_arguments(juliancentury)
+	node := moon_mean_node(juliancentury)
+
+	sinI, cosI := math.Sincos(radians(moonEquatorInclination))
+	sinW, cosW := math.Sincos(radians(longitude - node))
+	sinb, cosb := math.Sincos(radians(latitude))
+
+	a := degrees(math.Atan2(sinW*cosb*cosI-sinb*sinI, cosW*cosb))
+	opticalLongitude := angle_difference(a-f, 0)
+	opticalLatitude := degrees(math.Asin(clamp(-sinW*cosb*sinI-sinb*cosI, -1, 1)))
+
+	rho, sigma, tau := moon_physical_libration_terms(juliancentury)
+	sina, cosa := math.Sincos(radians(a))
+	physicalLongitude := -tau + (rho*cosa+sigma*sina)*math.Tan(radians(opticalLatitude))
+	physicalLatitude := sigma*cosa - rho*sina
+
+	return opticalLongitude, opticalLatitude, physicalLongitude, physicalLatitude
+}
+
+// Calculate the quantities ρ, σ and τ of the physical libration in degrees
+//
+// See Meeus, Astronomical Algorithms, chapter 53
+func moon_physical_libration_terms(juliancentury float64) (float64, float64, float64) {
+	t := juliancentury
+	_, d, m, mp, f := moon_arguments(t)
+	node := moon_mean_node(t)
+	e := 1 - 0.002516*t - 0.0000074*t*t
+	k1 := radians(119.75 + 131.849*t)
+	k2 := radians(72.56 + 20.186*t)
+	d, m, mp, f, node = radians(d), radians(m), radians(mp), radians(f), radians(node)
+
+	rho := -0.02752*math.Cos(mp) - 0.02245*math.Sin(f) + 0.00684*math.Cos(mp-2*f) -
+		0.00293*math.Cos(2*f) - 0.00085*math.Cos(2*f-2*d) - 0.00054*math.Cos(mp-2*d) -
+		0.00020*math.Sin(mp+f) - 0.00020*math.Cos(mp+2*f) - 0.00020*math.Cos(mp-f) +
+		0.00014*math.Cos(mp+2*f-2*d)
+
+	sigma := -0.02816*math.Sin(mp) + 0.02244*math.Cos(f) - 0.00682*math.Sin(mp-2*f) -
+		0.00279*math.Sin(2*f) - 0.00083*math.Sin(2*f-2*d) + 0.00069*math.Sin(mp-2*d) +
+		0.00040*math.Cos(mp+f) - 0.00025*math.Sin(2*mp) - 0.00023*math.Sin(mp+2*f) +
+		0.00020*math.Cos(mp-f) + 0.00019*math.Sin(mp-f) + 0.00013*math.Sin(mp+2*f-2*d) -
+		0.00010*math.Cos(mp-3*f)
+
+	tau := 0.02520*e*math.Sin(m) + 0.00473*math.Sin(2*mp-2*f) - 0.00467*math.Sin(mp) +
+		0.00396*math.Sin(k1) + 0.00276*math.Sin(2*mp-2*d) + 0.00196*math.Sin(node) -
+		0.00183*math.Cos(mp-f) + 0.00115*math.Sin(mp-2*d) - 0.00096*math.Sin(mp-d) +
+		0.00046*math.Sin(2*f-2*d) - 0.00039*math.Sin(mp-f) - 0.00032*math.Sin(mp-m-d) +
+		0.00027*math.Sin(2*mp-m-2*d) + 0.00023*math.Sin(k2) - 0.00014*math.Sin(2*d) +
+		0.00014*math.Cos(2*mp-2*f) - 0.00012*math.Sin(mp-2*f) - 0.00012*math.Sin(2*mp) +
+		0.00011*math.Sin(2*mp-2*m-2*d)
+
+	return rho, sigma, tau
+}
+
+// Calculate the physical ephemeris of the moon
+// Args:
+//
+//	juliancentury: The Julian Century on the TT scale
+func moon_physical(juliancentury float64) MoonPhysicalEphemeris {
+	jc := juliancentury
+	longitude, latitude, distance := moon_position(jc)
+	psi, eps := nutation(jc)
+	obliquity := mean_obliquity_of_ecliptic(jc) + eps
+
+	var ephemeris MoonPhysicalEphemeris
+	l1, b1, l2, b2 := moon_libration(jc, longitude, latitude)
+	ephemeris.OpticalLibrationLongitude, ephemeris.OpticalLibrationLatitude = l1, b1
+	ephemeris.PhysicalLibrationLongitude, ephemeris.PhysicalLibrationLatitude = l2, b2
+	ephemeris.LibrationLongitude, ephemeris.LibrationLatitude = l1+l2, b1+b2
+
+	// the position angle of the axis from the apparent right ascension of the moon
+	node := moon_mean_node(jc)
+	rho, sigma, _ := moon_physical_libration_terms(jc)
+	ra, _ := ecliptic_to_equatorial(longitude+psi, latitude, obliquity)
+	inclination := radians(moonEquatorInclination + rho)
+	v := radians(node + psi + sigma/math.Sin(radians(moonEquatorInclination)))
+	x := math.Sin(inclination) * math.Sin(v)
+	y := math.Sin(inclination)*math.Cos(v)*math.Cos(radians(obliquity)) - math.Cos(inclination)*math.Sin(radians(obliquity))
+	omega := math.Atan2(x, y)
+	sinp := math.Hypot(x, y) * math.Cos(radians(ra)-omega) / math.Cos(radians(ephemeris.LibrationLatitude))
+	ephemeris.PositionAngle = properAngle(degrees(math.Asin(clamp(sinp, -1, 1))))
+
+	// the selenographic position of the sun follows from the heliocentric direction of the moon
+	sunLongitude, _, sunDistance := sun_apparent_position(jc)
+	sunLongitude -= psi
+	ratio := distance / (sunDistance * AstronomicalUnit)
+	helioLongitude := sunLongitude + 180 + degrees(ratio*math.Cos(radians(latitude))*math.Sin(radians(sunLongitude-longitude)))
+	helioLatitude := ratio * latitude
+	l1, b1, l2, b2 = moon_libration(jc, helioLongitude, helioLatitude)
+	ephemeris.SubsolarLongitude = angle_difference(l1+l2, 0)
+	ephemeris.SubsolarLatitude = b1 + b2
+	ephemeris.Colongitude = properAngle(90 - ephemeris.SubsolarLongitude)
+	return ephemeris
+}
+
+// MoonPhysical calculates the physical ephemeris of the moon: its libration, the position
+// angle of its axis and the selenographic position of the sun, which tells which craters
+// are lit.
+//
+// See Meeus, Astronomical Algorithms, chapter 53
+// Returns:
+//
+//	The ephemeris for the centre of the earth. The libration seen by an observer differs by
+//	up to a degree because of the parallax.
+func MoonPhysical(dateandtime time.Time) MoonPhysicalEphemeris {
+	return moon_physical(jday_to_jcentury(julian_ephemeris_day(dateandtime)))
+}
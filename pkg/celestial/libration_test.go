@@ -0,0 +1,51 @@
+package celestial
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMoonPhysical(t *testing.T) {
+	// See Meeus, Astronomical Algorithms, example 53.a, 1992 April 12 at 0h TD
+	ephemeris := moon_physical(jday_to_jcentury(2448724.5))
+	almostEqualFloat(t, ephemeris.OpticalLibrationLongitude, -1.206, 0.001)
+	almostEqualFloat(t, ephemeris.OpticalLibrationLatitude, 4.194, 0.001)
+	almostEqualFloat(t, ephemeris.PhysicalLibrationLongitude, -0.025, 0.001)
+	almostEqualFloat(t, ephemeris.PhysicalLibrationLatitude, 0.006, 0.001)
+	almostEqualFloat(t, ephemeris.LibrationLongitude, -1.23, 0.005)
+	almostEqualFloat(t, ephemeris.LibrationLatitude, 4.20, 0.005)
+	almostEqualFloat(t, ephemeris.PositionAngle, 15.08, 0.005)
+	almostEqualFloat(t, ephemeris.SubsolarLongitude, 67.89, 0.005)
+	almostEqualFloat(t, ephemeris.SubsolarLatitude, 1.46, 0.005)
+	almostEqualFloat(t, ephemeris.Colongitude, 22.11, 0.005)
+}
+
+func TestMoonColongitude(t *testing.T) {
+	// the morning terminator runs through the centre of the disk at first quarter and
+	// along the eastern limb at full moon, within the libration
+	var tests = []struct {
+		phase LunarPhase
+		want  float64
+	}{
+		{FirstQuarter, 0},
+		{FullMoon, 90},
+		{LastQuarter, 180},
+		{NewMoon, 270},
+	}
+
+	for _, test := range tests {
+		t.Run(test.phase.String(), func(t *testing.T) {
+			for _, phase := range LunarPhases(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)) {
+				if phase.Phase != test.phase {
+					continue
+				}
+				ephemeris := MoonPhysical(phase.Time)
+				almostEqualFloat(t, angle_difference(ephemeris.Colongitude, test.want), 0, 10)
+				if ephemeris.LibrationLongitude < -8 || ephemeris.LibrationLongitude > 8 ||
+					ephemeris.LibrationLatitude < -7 || ephemeris.LibrationLatitude > 7 {
+					t.Fatalf("%v: libration %f, %f", phase.Time, ephemeris.LibrationLongitude, ephemeris.LibrationLatitude)
+				}
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package celestial
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"time"
@@ -81,3 +82,374 @@ func MoonPhaseDescription(x float64) (string, error) {
 
 	return "", fmt.Errorf("failed parsing %v", x)
 }
+
+// Periodic terms for the longitude (Σl) and distance (Σr) of the moon
+// in units of 0.000001 degrees and 0.001 km.
+//
+// See Meeus, Astronomical Algorithms, table 47.A
+var moonLongitudeDistanceTerms = [...]struct {
+	d, m, mp, f float64
+	sl, sr      float64
+}{
+	{0, 0, 1, 0, 6288774, -20905355},
+	{2, 0, -1, 0, 1274027, -3699111},
+	{2, 0, 0, 0, 658314, -2955968},
+	{0, 0, 2, 0, 213618, -569925},
+	{0, 1, 0, 0, -185116, 48888},
+	{0, 0, 0, 2, -114332, -3149},
+	{2, 0, -2, 0, 58793, 246158},
+	{2, -1, -1, 0, 57066, -152138},
+	{2, 0, 1, 0, 53322, -170733},
+	{2, -1, 0, 0, 45758, -204586},
+	{0, 1, -1, 0, -40923, -129620},
+	{1, 0, 0, 0, -34720, 108743},
+	{0, 1, 1, 0, -30383, 104755},
+	{2, 0, 0, -2, 15327, 10321},
+	{0, 0, 1, 2, -12528, 0},
+	{0, 0, 1, -2, 10980, 79661},
+	{4, 0, -1, 0, 10675, -34782},
+	{0, 0, 3, 0, 10034, -23210},
+	{4, 0, -2, 0, 8548, -21636},
+	{2, 1, -1, 0, -7888, 24208},
+	{2, 1, 0, 0, -6766, 30824},
+	{1, 0, -1, 0, -5163, -8379},
+	{1, 1, 0, 0, 4987, -16675},
+	{2, -1, 1, 0, 4036, -12831},
+	{2, 0, 2, 0, 3994, -10445},
+	{4, 0, 0, 0, 3861, -11650},
+	{2, 0, -3, 0, 3665, 14403},
+	{0, 1, -2, 0, -2689, -7003},
+	{2, 0, -1, 2, -2602, 0},
+	{2, -1, -2, 0, 2390, 10056},
+	{1, 0, 1, 0, -2348, 6322},
+	{2, -2, 0, 0, 2236, -9884},
+	{0, 1, 2, 0, -2120, 5751},
+	{0, 2, 0, 0, -2069, 0},
+	{2, -2, -1, 0, 2048, -4950},
+	{2, 0, 1, -2, -1773, 4130},
+	{2, 0, 0, 2, -1595, 0},
+	{4, -1, -1, 0, 1215, -3958},
+	{0, 0, 2, 2, -1110, 0},
+	{3, 0, -1, 0, -892, 3258},
+	{2, 1, 1, 0, -810, 2616},
+	{4, -1, -2, 0, 759, -1897},
+	{0, 2, -1, 0, -713, -2117},
+	{2, 2, -1, 0, -700, 2354},
+	{2, 1, -2, 0, 691, 0},
+	{2, -1, 0, -2, 596, 0},
+	{4, 0, 1, 0, 549, -1423},
+	{0, 0, 4, 0, 537, -1117},
+	{4, -1, 0, 0, 520, -1571},
+	{1, 0, -2, 0, -487, -1739},
+	{2, 1, 0, -2, -399, 0},
+	{0, 0, 2, -2, -381, -4421},
+	{1, 1, 1, 0, 351, 0},
+	{3, 0, -2, 0, -340, 0},
+	{4, 0, -3, 0, 330, 0},
+	{2, -1, 2, 0, 327, 0},
+	{0, 2, 1, 0, -323, 1165},
+	{1, 1, -1, 0, 299, 0},
+	{2, 0, 3, 0, 294, 0},
+	{2, 0, -1, -2, 0, 8752},
+}
+
+// Periodic terms for the latitude (Σb) of the moon in units of 0.000001 degrees.
+//
+// See Meeus, Astronomical Algorithms, table 47.B
+var moonLatitudeTerms = [...]struct {
+	d, m, mp, f float64
+	sb          float64
+}{
+	{0, 0, 0, 1, 5128122},
+	{0, 0, 1, 1, 280602},
+	{0, 0, 1, -1, 277693},
+	{2, 0, 0, -1, 173237},
+	{2, 0, -1, 1, 55413},
+	{2, 0, -1, -1, 46271},
+	{2, 0, 0, 1, 32573},
+	{0, 0, 2, 1, 17198},
+	{2, 0, 1, -1, 9266},
+	{0, 0, 2, -1, 8822},
+	{2, -1, 0, -1, 8216},
+	{2, 0, -2, -1, 4324},
+	{2, 0, 1, 1, 4200},
+	{2, 1, 0, -1, -3359},
+	{2, -1, -1, 1, 2463},
+	{2, -1, 0, 1, 2211},
+	{2, -1, -1, -1, 2065},
+	{0, 1, -1, -1, -1870},
+	{4, 0, -1, -1, 1828},
+	{0, 1, 0, 1, -1794},
+	{0, 0, 0, 3, -1749},
+	{0, 1, -1, 1, -1565},
+	{1, 0, 0, 1, -1491},
+	{0, 1, 1, 1, -1475},
+	{0, 1, 1, -1, -1410},
+	{0, 1, 0, -1, -1344},
+	{1, 0, 0, -1, -1335},
+	{0, 0, 3, 1, 1107},
+	{4, 0, 0, -1, 1021},
+	{4, 0, -1, 1, 833},
+	{0, 0, 1, -3, 777},
+	{4, 0, -2, 1, 671},
+	{2, 0, 0, -3, 607},
+	{2, 0, 2, -1, 596},
+	{2, -1, 1, -1, 491},
+	{2, 0, -2, 1, -451},
+	{0, 0, 3, -1, 439},
+	{2, 0, 2, 1, 422},
+	{2, 0, -3, -1, 421},
+	{2, 1, -1, 1, -366},
+	{2, 1, 0, 1, -351},
+	{4, 0, 0, 1, 331},
+	{2, -1, 1, 1, 315},
+	{2, -2, 0, -1, 302},
+	{0, 0, 1, 3, -283},
+	{2, 1, 1, -1, -229},
+	{1, 1, 0, -1, 223},
+	{1, 1, 0, 1, 223},
+	{0, 1, -2, -1, -220},
+	{2, 1, -1, -1, -220},
+	{1, 0, 1, 1, -185},
+	{2, -1, -2, -1, 181},
+	{0, 1, 2, 1, -177},
+	{4, 0, -2, -1, 176},
+	{4, -1, -1, -1, 166},
+	{1, 0, 1, -1, -164},
+	{4, 0, 1, -1, 132},
+	{1, 0, -1, -1, -119},
+	{4, -1, 0, -1, 115},
+	{2, -2, 0, 1, 107},
+}
+
+// Calculate the fundamental arguments of the lunar theory
+// Returns:
+//
+//	The moon's mean longitude L', the mean elongation D, the sun's mean anomaly M,
+//	the moon's mean anomaly M' and its argument of latitude F, all in degrees.
+func moon_arguments(juliancentury float64) (float64, float64, float64, float64, float64) {
+	t := juliancentury
+	t2 := t * t
+	t3 := t2 * t
+	t4 := t3 * t
+
+	lp := 218.3164477 + 481267.88123421*t - 0.0015786*t2 + t3/538841 - t4/65194000
+	d := 297.8501921 + 445267.1114034*t - 0.0018819*t2 + t3/545868 - t4/113065000
+	m := 357.5291092 + 35999.0502909*t - 0.0001536*t2 + t3/24490000
+	mp := 134.9633964 + 477198.8675055*t + 0.0087414*t2 + t3/69699 - t4/14712000
+	f := 93.2720950 + 483202.0175233*t - 0.0036539*t2 - t3/3526000 + t4/863310000
+
+	return properAngle(lp), properAngle(d), properAngle(m), properAngle(mp), properAngle(f)
+}
+
+// Calculate the longitude of the mean ascending node of the moon's orbit
+//
+// See Meeus, Astronomical Algorithms, chapter 47
+// Returns:
+//
+//	The longitude in degrees referred to the mean equinox of date.
+func moon_mean_node(juliancentury float64) float64 {
+	t := juliancentury
+	return properAngle(125.0445479 - 1934.1362891*t + 0.0020754*t*t + t*t*t/467441 - t*t*t*t/60616000)
+}
+
+// Calculate the geocentric position of the moon referred to the mean equinox of date
+//
+// See Meeus, Astronomical Algorithms, chapter 47
+// Args:
+//
+//	juliancentury: The Julian Century on the Terrestrial Time scale
+//
+// Returns:
+//
+//	The ecliptic longitude and latitude in degrees and the distance in kilometres.
+func moon_position(juliancentury float64) (float64, float64, float64) {
+	t := juliancentury
+	lp, d, m, mp, f := moon_arguments(t)
+
+	a1 := radians(119.75 + 131.849*t)
+	a2 := radians(53.09 + 479264.290*t)
+	a3 := radians(313.45 + 481266.484*t)
+	e := 1 - 0.002516*t - 0.0000074*t*t
+
+	eccentricity := func(m float64) float64 {
+		switch math.Abs(m) {
+		case 1:
+			return e
+		case 2:
+			return e * e
+		}
+		return 1
+	}
+
+	var sl, sr, sb float64
+	for _, term := range moonLongitudeDistanceTerms {
+		arg := radians(term.d*d + term.m*m + term.mp*mp + term.f*f)
+		sinarg, cosarg := math.Sincos(arg)
+		sl += term.sl * eccentricity(term.m) * sinarg
+		sr += term.sr * eccentricity(term.m) * cosarg
+	}
+	for _, term := range moonLatitudeTerms {
+		arg := radians(term.d*d + term.m*m + term.mp*mp + term.f*f)
+		sb += term.sb * eccentricity(term.m) * math.Sin(arg)
+	}
+
+	// additive terms for the action of Venus and Jupiter and the flattening of the earth
+	sl += 3958*math.Sin(a1) + 1962*math.Sin(radians(lp-f)) + 318*math.Sin(a2)
+	sb += -2235*math.Sin(radians(lp)) + 382*math.Sin(a3) + 175*math.Sin(a1-radians(f)) +
+		175*math.Sin(a1+radians(f)) + 127*math.Sin(radians(lp-mp)) - 115*math.Sin(radians(lp+mp))
+
+	longitude := properAngle(lp + sl/1000000)
+	latitude := sb / 1000000
+	distance := 385000.56 + sr/1000
+	return longitude, latitude, distance
+}
+
+// Calculate the apparent geocentric right ascension, declination and distance of the moon
+// Args:
+//
+//	dateandtime: The date and time to calculate for
+//
+// Returns:
+//
+//	The right ascension and declination in degrees and the distance in kilometres.
+func moon_equatorial(dateandtime time.Time) (float64, float64, float64) {
+	jc := jday_to_jcentury(julian_ephemeris_day(dateandtime))
+	longitude, latitude, distance := moon_position(jc)
+	longitude += nutation_in_longitude(jc)
+
+	ra, dec := ecliptic_to_equatorial(longitude, latitude, obliquity_correction(jc))
+	return ra, dec, distance
+}
+
+// Calculate the topocentric position of the moon
+// Returns:
+//
+//	The elevation above the horizon and azimuth clockwise from North in degrees and
+//	the distance from the observer in kilometres.
+func moon_topocentric(observer Observer, dateandtime time.Time) (float64, float64, float64) {
+	ra, dec, distance := moon_equatorial(dateandtime)
+
+	lst := greenwich_apparent_sidereal_time(julian_datetime(dateandtime)) + observer.Longitude
+	ra, dec, distance = equatorial_to_topocentric(observer, lst, ra, dec, distance)
+
+	elevation, azimuth := equatorial_to_horizontal(observer.Latitude, lst-ra, dec)
+	return elevation, azimuth, distance
+}
+
+// MoonZenithAndAzimuth calculates the topocentric zenith and azimuth angles of the moon.
+// Args:
+//
+//	observer:        Observer to calculate for
+//	dateandtime:     The date and time for which to calculate the angles.
+//	with_refraction: If True adjust zenith to take refraction into account
+//
+// Returns:
+//
+//	The zenith angle and the azimuth clockwise from North in degrees.
+func MoonZenithAndAzimuth(observer Observer, dateandtime time.Time, with_refraction bool) (float64, float64) {
+	elevation, azimuth, _ := moon_topocentric(observer, dateandtime)
+	zenith := 90 - elevation
+	if with_refraction {
+		zenith -= refraction_at_zenith(zenith)
+	}
+	return zenith, azimuth
+}
+
+// MoonElevation calculates the moon's angle of elevation as seen by the observer.
+// Returns:
+//
+//	The elevation angle in degrees above the horizon.
+func MoonElevation(observer Observer, dateandtime time.Time, with_refraction bool) float64 {
+	zenith, _ := MoonZenithAndAzimuth(observer, dateandtime, with_refraction)
+	return 90 - zenith
+}
+
+// MoonAzimuth calculates the azimuth angle of the moon as seen by the observer.
+// Returns:
+//
+//	The azimuth angle in degrees clockwise from North.
+func MoonAzimuth(observer Observer, dateandtime time.Time) float64 {
+	_, azimuth := MoonZenithAndAzimuth(observer, dateandtime, true)
+	return azimuth
+}
+
+var (
+	ErrNoMoonrise = errors.New("moon does not rise on this day, at this location")
+	ErrNoMoonset  = errors.New("moon does not set on this day, at this location")
+)
+
+// Moonrise calculates when the moon rises on a day, see RiseTransitSet.
+// Args:
+//
+//	observer: Observer to calculate moonrise for
+//	date:     Date to calculate for. The day is taken in the date's timezone.
+//
+// Returns:
+//
+//	Date and time at which the moon rises, or ErrNoMoonrise on the day each month that
+//	the moon skips its rising.
+func Moonrise(observer Observer, date time.Time) (time.Time, error) {
+	times, err := RiseTransitSet(Moon, observer, date)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if times.Rise.IsZero() {
+		return time.Time{}, ErrNoMoonrise
+	}
+	return times.Rise, nil
+}
+
+// Moonset calculates when the moon sets on a day, see RiseTransitSet.
+// Args:
+//
+//	observer: Observer to calculate moonset for
+//	date:     Date to calculate for. The day is taken in the date's timezone.
+//
+// Returns:
+//
+//	Date and time at which the moon sets, or ErrNoMoonset on the day each month that
+//	the moon skips its setting.
+func Moonset(observer Observer, date time.Time) (time.Time, error) {
+	times, err := RiseTransitSet(Moon, observer, date)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if times.Set.IsZero() {
+		return time.Time{}, ErrNoMoonset
+	}
+	return times.Set, nil
+}
+
+// MoonDistance calculates the distance between the centres of the earth and the moon.
+// Returns:
+//
+//	The distance in kilometres.
+func MoonDistance(dateandtime time.Time) float64 {
+	jc := jday_to_jcentury(julian_ephemeris_day(dateandtime))
+	_, _, distance := moon_position(jc)
+	return distance
+}
+
+// Calculate the angular radius of the moon seen from the given distance in kilometres
+func moon_apparent_radius(distance float64) float64 {
+	// the moon's radius is 0.272481 earth equatorial radii
+	return degrees(math.Asin(0.272481 * earthEquatorialRadius / distance))
+}
+
+// MoonIllumination calculates the illuminated fraction of the moon's disk.
+//
+// See Meeus, Astronomical Algorithms, chapter 48
+// Returns:
+//
+//	The fraction between 0 (new moon) and 1 (full moon).
+func MoonIllumination(dateandtime time.Time) float64 {
+	jc := jday_to_jcentury(julian_ephemeris_day(dateandtime))
+	_, d, m, mp, _ := moon_arguments(jc)
+	d, m, mp = radians(d), radians(m), radians(mp)
+
+	// the phase angle, i.e. the angle sun-moon-earth
+	i := 180 - degrees(d) - 6.289*math.Sin(mp) + 2.100*math.Sin(m) - 1.274*math.Sin(2*d-mp) -
+		0.658*math.Sin(2*d) - 0.214*math.Sin(2*mp) - 0.110*math.Sin(d)
+	return (1 + math.Cos(radians(i))) / 2
+}
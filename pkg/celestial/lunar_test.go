@@ -28,3 +28,107 @@ func TestMoon(t *testing.T) {
 		})
 	}
 }
+
+func TestMoonPosition(t *testing.T) {
+	// Meeus, Astronomical Algorithms, example 47.a
+	jc := jday_to_jcentury(2448724.5)
+	longitude, latitude, distance := moon_position(jc)
+	almostEqualFloat(t, longitude, 133.162655, 0.000001)
+	almostEqualFloat(t, latitude, -3.229126, 0.000001)
+	almostEqualFloat(t, distance, 368409.7, 0.1)
+}
+
+func TestMoonDistance(t *testing.T) {
+	type args struct {
+		date time.Time
+	}
+	tests := []struct {
+		name string
+		args args
+		want float64
+	}{
+		// perigee and apogee
+		{args: args{date: time.Date(2024, 4, 7, 17, 52, 0, 0, time.UTC)}, want: 358850},
+		{args: args{date: time.Date(2024, 4, 20, 2, 9, 0, 0, time.UTC)}, want: 405623},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MoonDistance(tt.args.date)
+			almostEqualFloat(t, got, tt.want, 50)
+		})
+	}
+}
+
+func TestMoonIllumination(t *testing.T) {
+	type args struct {
+		date time.Time
+	}
+	tests := []struct {
+		name string
+		args args
+		want float64
+	}{
+		{args: args{date: time.Date(2024, 3, 25, 7, 0, 0, 0, time.UTC)}, want: 1},
+		{args: args{date: time.Date(2024, 4, 8, 18, 21, 0, 0, time.UTC)}, want: 0},
+		{args: args{date: time.Date(2024, 4, 15, 19, 13, 0, 0, time.UTC)}, want: 0.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MoonIllumination(tt.args.date)
+			almostEqualFloat(t, got, tt.want, 0.01)
+		})
+	}
+}
+
+func TestMoonZenithAndAzimuth(t *testing.T) {
+	// during a total solar eclipse the moon covers the sun
+	type args struct {
+		observer Observer
+		date     time.Time
+	}
+	tests := []struct {
+		name string
+		args args
+	}{
+		{name: "Dallas 2024", args: args{observer: Observer{Latitude: 32.8975, Longitude: -97.0404}, date: time.Date(2024, 4, 8, 18, 42, 0, 0, time.UTC)}},
+		{name: "Madras 2017", args: args{observer: Observer{Latitude: 44.6333, Longitude: -121.1295, Elevation: 680}, date: time.Date(2017, 8, 21, 17, 20, 0, 0, time.UTC)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			zenith, azimuth := MoonZenithAndAzimuth(tt.args.observer, tt.args.date, false)
+			sunZenith, sunAzimuth := ZenithAndAzimuth(tt.args.observer, tt.args.date, false)
+			almostEqualFloat(t, angular_separation(90-zenith, azimuth, 90-sunZenith, sunAzimuth), 0, 0.05)
+		})
+	}
+}
+
+func TestMoonriseMoonset(t *testing.T) {
+	mecca := Observer{Latitude: 21.4225, Longitude: 39.8262}
+	loc := time.FixedZone("AST", 3*3600)
+
+	// the crescent of Shawwal 1445 set 53 minutes after the sun
+	moonset, err := Moonset(mecca, time.Date(2024, 4, 9, 0, 0, 0, 0, loc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	almostEqualTime(t, moonset, time.Date(2024, 4, 9, 19, 31, 0, 0, loc), 2*time.Minute)
+
+	// the moon skips one rising and one setting a month, as it comes back about 50 minutes
+	// later every day
+	var noRise, noSet int
+	for day := time.Date(2024, 4, 1, 0, 0, 0, 0, loc); day.Month() == 4; day = day.AddDate(0, 0, 1) {
+		if _, err := Moonrise(mecca, day); err == ErrNoMoonrise {
+			noRise++
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Moonset(mecca, day); err == ErrNoMoonset {
+			noSet++
+		} else if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if noRise != 1 || noSet != 1 {
+		t.Errorf("got %d days without moonrise and %d without moonset", noRise, noSet)
+	}
+}
@@ -0,0 +1,254 @@
+package celestial
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+//go:embed data/meteorshowers.csv
+var meteorShowersCSV string
+
+var (
+	meteorShowers     []MeteorShower
+	meteorShowersOnce sync.Once
+)
+
+// MeteorShower is an annual meteor shower of the IMO working list. The activity is given
+// as solar longitudes referred to the equinox J2000, which repeat every year unlike dates.
+type MeteorShower struct {
+	// Code is the three letter IAU code, e.g. PER for the Perseids
+	Code string
+	Name string
+	// Begin, Peak and End are the solar longitudes of the activity in degrees
+	Begin float64
+	Peak  float64
+	End   float64
+	// RightAscension and Declination are the J2000 position of the radiant at the peak in
+	// degrees, DriftRA and DriftDec its daily motion in degrees
+	RightAscension float64
+	Declination    float64
+	DriftRA        float64
+	DriftDec       float64
+	// Velocity is the speed of the meteors entering the atmosphere in km/s
+	Velocity float64
+	// ZHR is the zenithal hourly rate at the peak, the number of meteors a single observer
+	// would see in an hour under a perfectly dark sky with the radiant in the zenith
+	ZHR float64
+}
+
+// LoadMeteorShowers reads a list of meteor showers in the format of the embedded list, one
+// shower per line with the fields
+//
+//	code,name,begin,peak,end (solar longitude J2000),ra,dec,drift ra,drift dec (deg/day),velocity (km/s),zhr
+//
+// Lines starting with # are comments.
+func LoadMeteorShowers(r io.Reader) ([]MeteorShower, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = 11
+
+	var showers []MeteorShower
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		shower := MeteorShower{Code: strings.TrimSpace(record[0]), Name: strings.TrimSpace(record[1])}
+		fields := []*float64{
+			&shower.Begin, &shower.Peak, &shower.End,
+			&shower.RightAscension, &shower.Declination, &shower.DriftRA, &shower.DriftDec,
+			&shower.Velocity, &shower.ZHR,
+		}
+		var errs [9]error
+		for i, field := range fields {
+			*field, errs[i] = strconv.ParseFloat(strings.TrimSpace(record[i+2]), 64)
+		}
+		if err := errors.Join(errs[:]...); err != nil {
+			line, _ := reader.FieldPos(0)
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		showers = append(showers, shower)
+	}
+	return showers, nil
+}
+
+// MajorMeteorShowers returns the embedded list of the major annual meteor showers, in the
+// order of their peaks from the start of the year.
+func MajorMeteorShowers() []MeteorShower {
+	meteorShowersOnce.Do(func() {
+		showers, err := LoadMeteorShowers(strings.NewReader(meteorShowersCSV))
+		if err != nil {
+			panic(fmt.Sprintf("embedded meteor shower list: %v", err))
+		}
+		meteorShowers = showers
+	})
+	return append([]MeteorShower(nil), meteorShowers...)
+}
+
+// Calculate the longitude of the sun referred to the mean equinox J2000, the solar
+// longitude used for meteor showers
+func sun_longitude_j2000(dateandtime time.Time) float64 {
+	jc := jday_to_jcentury(julian_ephemeris_day(dateandtime))
+	longitude, _, _ := sun_apparent_position(jc)
+	precession := (5029.0966*jc + 1.11113*jc*jc) / 3600
+	return properAngle(longitude - nutation_in_longitude(jc) - precession)
+}
+
+// Find the time near a given time at which the sun reaches a longitude
+// Args:
+//
+//	longitudeAt: The longitude of the sun at a time, e.g. SunApparentLongitude
+//	longitude:   The longitude to find in degrees
+//	near:        A time less than half a year from the result
+//
+// Returns:
+//
+//	The time, to about a second.
+func sun_longitude_time(longitudeAt func(time.Time) float64, longitude float64, near time.Time) time.Time {
+	// the sun moves by about a degree a day, a few Newton steps with that rate are enough
+	const degreesPerDay = 360 / 365.2422
+	t := near
+	for i := 0; i < 10; i++ {
+		days := angle_difference(longitude, longitudeAt(t)) / degreesPerDay
+		t = t.Add(time.Duration(days * 24 * float64(time.Hour)))
+		if math.Abs(days) < 1e-6 {
+			break
+		}
+	}
+	return t.Round(time.Second)
+}
+
+// MeteorShowerPeak is the activity of a meteor shower in a given year.
+type MeteorShowerPeak struct {
+	Shower MeteorShower
+	Begin  time.Time
+	Peak   time.Time
+	End    time.Time
+}
+
+// MeteorShowers calculates the dates of the major meteor showers peaking in a year, see
+// MajorMeteorShowers.
+// Returns:
+//
+//	The activity of the showers in UTC, sorted by their peaks.
+func MeteorShowers(year int) []MeteorShowerPeak {
+	var peaks []MeteorShowerPeak
+	for _, shower := range MajorMeteorShowers() {
+		// start half a year before the estimated date, so that the search converges on the
+		// peak of this year
+		start := time.Date(year, 3, 20, 0, 0, 0, 0, time.UTC)
+		near := start.Add(time.Duration(properAngle(shower.Peak) / 360 * 365.2422 * 24 * float64(time.Hour)))
+		peak := sun_longitude_time(sun_longitude_j2000, shower.Peak, near)
+		if peak.Year() != year {
+			peak = sun_longitude_time(sun_longitude_j2000, shower.Peak, near.AddDate(year-peak.Year(), 0, 0))
+		}
+		peaks = append(peaks, MeteorShowerPeak{
+			Shower: shower,
+			Begin:  sun_longitude_time(sun_longitude_j2000, shower.Begin, peak.Add(-time.Duration(angle_difference(shower.Peak, shower.Begin)*24*float64(time.Hour)))),
+			Peak:   peak,
+			End:    sun_longitude_time(sun_longitude_j2000, shower.End, peak.Add(time.Duration(angle_difference(shower.End, shower.Peak)*24*float64(time.Hour)))),
+		})
+	}
+	sort.SliceStable(peaks, func(i, j int) bool { return peaks[i].Peak.Before(peaks[j].Peak) })
+	return peaks
+}
+
+// Radiant calculates the position of the radiant of a shower, moved by its drift from the
+// position at the peak.
+// Returns:
+//
+//	The right ascension and declination referred to the mean equator and equinox of date
+//	in degrees.
+func (p MeteorShowerPeak) Radiant(dateandtime time.Time) (float64, float64) {
+	days := dateandtime.Sub(p.Peak).Hours() / 24
+	ra := p.Shower.RightAscension + p.Shower.DriftRA*days
+	dec := p.Shower.Declination + p.Shower.DriftDec*days
+	return precess_equatorial_from_j2000(jday_to_jcentury(julian_ephemeris_day(dateandtime)), ra, dec)
+}
+
+// MeteorVisibility rates the conditions for watching a meteor shower during one night.
+type MeteorVisibility struct {
+	// Dusk and Dawn are the start and end of the astronomical darkness
+	Dusk time.Time
+	Dawn time.Time
+	// Best is the time of the highest score during the darkness
+	Best             time.Time
+	RadiantElevation float64
+	MoonElevation    float64
+	MoonIllumination float64
+	// Score rates the conditions at the best time from 0 to 1, see Visibility
+	Score float64
+	// Rate is the number of meteors an observer can expect in an hour at the best time
+	Rate float64
+}
+
+// Visibility rates how well an observer can watch a meteor shower in the night of its peak.
+//
+// The darkness from astronomical dusk to dawn is sampled every 10 minutes. At each time the
+// score is the sine of the elevation of the radiant, which scales the zenithal hourly rate
+// to the rate seen by the observer, reduced by the moonlight. The moonlight is the
+// illuminated fraction of the moon when it stands in the zenith and half of that when it
+// stands on the horizon, so a full moon high in the sky leaves no score at all.
+// Args:
+//
+//	observer: Observer to calculate for
+//	loc:      The timezone of the observer, which decides the night of the peak
+//
+// Returns:
+//
+//	The conditions at the best time of the night, or an error if the sun does not reach
+//	astronomical darkness in that night.
+func (p MeteorShowerPeak) Visibility(observer Observer, loc *time.Location) (MeteorVisibility, error) {
+	// a peak in the morning belongs to the night that started the evening before
+	local := p.Peak.In(loc)
+	if local.Hour() < 12 {
+		local = local.AddDate(0, 0, -1)
+	}
+	evening := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+
+	dusk, err := Dusk(observer, evening, DepressionAstronomical)
+	if err != nil {
+		return MeteorVisibility{}, err
+	}
+	dawn, err := Dawn(observer, evening.AddDate(0, 0, 1), DepressionAstronomical)
+	if err != nil {
+		return MeteorVisibility{}, err
+	}
+
+	visibility := MeteorVisibility{Dusk: dusk, Dawn: dawn, Best: dusk}
+	for t := dusk; !t.After(dawn); t = t.Add(10 * time.Minute) {
+		lst := greenwich_apparent_sidereal_time(julian_datetime(t)) + observer.Longitude
+		ra, dec := p.Radiant(t)
+		radiant, _ := equatorial_to_horizontal(observer.Latitude, lst-ra, dec)
+		moon, _, _ := moon_topocentric(observer, t)
+		illumination := MoonIllumination(t)
+
+		moonlight := 0.0
+		if moon > 0 {
+			moonlight = illumination * (1 + math.Sin(radians(moon))) / 2
+		}
+		score := math.Max(0, math.Sin(radians(radiant))) * (1 - moonlight)
+		if t.Equal(dusk) || score > visibility.Score {
+			visibility.Best = t
+			visibility.RadiantElevation = radiant
+			visibility.MoonElevation = moon
+			visibility.MoonIllumination = illumination
+			visibility.Score = score
+		}
+	}
+	visibility.Rate = p.Shower.ZHR * visibility.Score
+	return visibility, nil
+}
@@ -0,0 +1,112 @@
+package celestial
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMajorMeteorShowers(t *testing.T) {
+	showers := MajorMeteorShowers()
+	if len(showers) < 10 {
+		t.Fatalf("got %d showers", len(showers))
+	}
+	for _, shower := range showers {
+		if angle_difference(shower.Peak, shower.Begin) <= 0 || angle_difference(shower.End, shower.Peak) <= 0 {
+			t.Fatalf("%s: peak %f outside %f to %f", shower.Code, shower.Peak, shower.Begin, shower.End)
+		}
+	}
+
+	if _, err := LoadMeteorShowers(strings.NewReader("PER,Perseids,114.6,x,151.0,48,+58,1.35,0.12,59,100\n")); err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestMeteorShowers(t *testing.T) {
+	// peak times of the IMO meteor shower calendars
+	var tests = []struct {
+		code string
+		peak time.Time
+	}{
+		{"QUA", time.Date(2024, 1, 4, 9, 0, 0, 0, time.UTC)},
+		{"PER", time.Date(2024, 8, 12, 13, 0, 0, 0, time.UTC)},
+		{"GEM", time.Date(2024, 12, 14, 1, 0, 0, 0, time.UTC)},
+		{"QUA", time.Date(2025, 1, 3, 15, 0, 0, 0, time.UTC)},
+		{"PER", time.Date(2025, 8, 12, 20, 0, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.code, func(t *testing.T) {
+			peaks := MeteorShowers(test.peak.Year())
+			for i, peak := range peaks {
+				if i > 0 && peak.Peak.Before(peaks[i-1].Peak) {
+					t.Fatalf("%s before %s", peak.Shower.Code, peaks[i-1].Shower.Code)
+				}
+				if peak.Peak.Year() != test.peak.Year() || !peak.Begin.Before(peak.Peak) || !peak.Peak.Before(peak.End) {
+					t.Fatalf("%s: %v %v %v", peak.Shower.Code, peak.Begin, peak.Peak, peak.End)
+				}
+				if peak.Shower.Code == test.code {
+					almostEqualTime(t, peak.Peak, test.peak, time.Hour)
+					almostEqualFloat(t, sun_longitude_j2000(peak.Peak), peak.Shower.Peak, 0.0001)
+					return
+				}
+			}
+			t.Fatalf("%s not found", test.code)
+		})
+	}
+}
+
+func TestSunLongitudeTime(t *testing.T) {
+	// the March equinox of 2024 at 03:06 UTC
+	equinox := sun_longitude_time(SunApparentLongitude, 0, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	almostEqualTime(t, equinox, time.Date(2024, 3, 20, 3, 6, 0, 0, time.UTC), time.Minute)
+}
+
+func TestMeteorShowerVisibility(t *testing.T) {
+	berlin := Observer{Latitude: 52.52, Longitude: 13.40}
+	loc, _ := time.LoadLocation("Europe/Berlin")
+	find := func(year int, code string) MeteorShowerPeak {
+		for _, peak := range MeteorShowers(year) {
+			if peak.Shower.Code == code {
+				return peak
+			}
+		}
+		t.Fatalf("%s not found", code)
+		return MeteorShowerPeak{}
+	}
+
+	// the Perseids radiate from high in the northern sky before dawn, with a thin moon set
+	// in 2024 and a waning gibbous moon in the sky in 2025
+	perseids2024, err := find(2024, "PER").Visibility(berlin, loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	perseids2025, err := find(2025, "PER").Visibility(berlin, loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perseids2024.Best.Before(perseids2024.Dusk) || perseids2024.Best.After(perseids2024.Dawn) {
+		t.Fatalf("best %v outside %v to %v", perseids2024.Best, perseids2024.Dusk, perseids2024.Dawn)
+	}
+	if perseids2024.MoonElevation > 0 || perseids2024.RadiantElevation < 50 {
+		t.Fatalf("moon %f, radiant %f", perseids2024.MoonElevation, perseids2024.RadiantElevation)
+	}
+	almostEqualFloat(t, perseids2024.Rate, perseids2024.Score*100, 1e-9)
+	if perseids2025.MoonElevation < 0 || perseids2025.Score > perseids2024.Score/2 {
+		t.Fatalf("2025 score %f, 2024 score %f", perseids2025.Score, perseids2024.Score)
+	}
+
+	// the radiant of the eta-Aquariids does not rise in the short nights of the north
+	aquariids, err := find(2024, "ETA").Visibility(berlin, loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if aquariids.Score != 0 {
+		t.Fatalf("score %f", aquariids.Score)
+	}
+
+	// no astronomical darkness in August in the north of Norway
+	if _, err := find(2024, "PER").Visibility(Observer{Latitude: 69.65, Longitude: 18.96}, loc); err == nil {
+		t.Fatal("expected an error")
+	}
+}
@@ -0,0 +1,106 @@
+package celestial
+
+import (
+	"time"
+)
+
+// GalacticCenter is the radio source Sagittarius A* at the centre of the Milky Way, whose
+// bright core around it is the favourite target of astrophotographers.
+var GalacticCenter = CatalogStar{
+	Name:           "Sgr A*",
+	RightAscension: 266.416837,
+	Declination:    -29.007811,
+}
+
+// GalacticCenterWindow is an interval in which the core of the Milky Way stands above a
+// chosen elevation in a dark sky.
+type GalacticCenterWindow struct {
+	Start        time.Time
+	StartAzimuth float64
+	End          time.Time
+	EndAzimuth   float64
+	// Best is the time of the highest elevation in the window, at the meridian if the core
+	// transits within the window
+	Best          time.Time
+	BestElevation float64
+	BestAzimuth   float64
+	// MoonElevation and MoonIllumination describe the moonlight at the best time
+	MoonElevation    float64
+	MoonIllumination float64
+	// MoonSeparation is the angle between the moon and the core at the best time in degrees
+	MoonSeparation float64
+}
+
+// GalacticCenterWindows finds when the core of the Milky Way can be photographed: when
+// the galactic centre stands above an elevation while the sun is below the astronomical
+// twilight. The moon is not excluded, its elevation, phase and distance from the core at
+// the best time of each window tell how much it brightens the sky.
+// Args:
+//
+//	observer:     Observer to calculate for
+//	from, to:     The time range to search, the nights are taken in the timezone of from
+//	minElevation: The geocentric elevation of the galactic centre in degrees, e.g. 10 to
+//	              clear the haze near the horizon
+//
+// Returns:
+//
+//	The windows in the range in the timezone of from, with the elevations and azimuths of
+//	the galactic centre in degrees. Elevations are without refraction.
+func GalacticCenterWindows(observer Observer, from, to time.Time, minElevation float64) []GalacticCenterWindow {
+	// the rising and setting are lowered by the dip of the horizon, which does not apply here
+	core := altitudeBody{GalacticCenter, minElevation + adjust_to_horizon(observer.Elevation)}
+
+	var windows []GalacticCenterWindow
+	first := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location()).AddDate(0, 0, -1)
+	for day := first; day.Before(to); day = day.AddDate(0, 0, 1) {
+		dusk, dawn, ok := astronomical_night(observer, day)
+		if !ok {
+			continue
+		}
+		if dusk.Before(from) {
+			dusk = from
+		}
+		if dawn.After(to) {
+			dawn = to
+		}
+		if !dusk.Before(dawn) {
+			continue
+		}
+
+		for _, above := range body_above(core, observer, dusk, dawn) {
+			windows = append(windows, galactic_center_window(observer, above, from.Location()))
+		}
+	}
+	return windows
+}
+
+// Describe the visibility of the galactic centre in an interval
+func galactic_center_window(observer Observer, above interval, loc *time.Location) GalacticCenterWindow {
+	// the core is highest at its transit, or at one end of the interval if it does not
+	// transit in between
+	best := above.start
+	startElevation, _ := body_horizontal(GalacticCenter, observer, above.start)
+	if endElevation, _ := body_horizontal(GalacticCenter, observer, above.end); endElevation > startElevation {
+		best = above.end
+	}
+	for _, day := range []time.Time{above.start, above.end} {
+		// the transit is also returned for a core that never sets
+		times, _ := RiseTransitSet(GalacticCenter, observer, day.In(loc))
+		if times.Transit.After(above.start) && times.Transit.Before(above.end) {
+			best = times.Transit
+		}
+	}
+
+	window := GalacticCenterWindow{
+		Start:            above.start.In(loc),
+		End:              above.end.In(loc),
+		Best:             best.In(loc),
+		MoonIllumination: MoonIllumination(best),
+	}
+	_, window.StartAzimuth = body_horizontal(GalacticCenter, observer, above.start)
+	_, window.EndAzimuth = body_horizontal(GalacticCenter, observer, above.end)
+	window.BestElevation, window.BestAzimuth = body_horizontal(GalacticCenter, observer, best)
+	window.MoonElevation, _, _ = moon_topocentric(observer, best)
+	window.MoonSeparation = Separation(GalacticCenter, Moon, best)
+	return window
+}
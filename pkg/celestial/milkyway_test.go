@@ -0,0 +1,64 @@
+package celestial
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGalacticCenterWindows(t *testing.T) {
+	moab := Observer{Latitude: 38.57, Longitude: -109.55, Elevation: 1230}
+	loc, _ := time.LoadLocation("America/Denver")
+	from := time.Date(2024, 6, 1, 12, 0, 0, 0, loc)
+	windows := GalacticCenterWindows(moab, from, from.AddDate(0, 0, 10), 10)
+	if len(windows) != 10 {
+		t.Fatalf("got %d windows", len(windows))
+	}
+
+	for _, window := range windows {
+		// the core culminates in the south during the astronomical night in June
+		almostEqualFloat(t, window.BestElevation, 90-moab.Latitude-29.0, 0.1)
+		almostEqualFloat(t, window.BestAzimuth, 180, 0.1)
+		if !window.Start.Before(window.Best) || !window.Best.Before(window.End) {
+			t.Fatalf("window %v, best %v, end %v", window.Start, window.Best, window.End)
+		}
+		if window.StartAzimuth > 180 || window.EndAzimuth < 180 {
+			t.Fatalf("azimuth from %f to %f", window.StartAzimuth, window.EndAzimuth)
+		}
+		for _, dateandtime := range []time.Time{window.Start, window.End} {
+			if elevation, _ := body_horizontal(GalacticCenter, moab, dateandtime); elevation < 10-0.01 {
+				t.Fatalf("%v: core at %f", dateandtime, elevation)
+			}
+			if elevation := Elevation(moab, dateandtime, false); elevation > -DepressionAstronomical+0.01 {
+				t.Fatalf("%v: sun at %f", dateandtime, elevation)
+			}
+		}
+	}
+
+	// new moon on June 6th
+	if windows[5].MoonIllumination > 0.01 || windows[5].MoonElevation > 0 {
+		t.Fatalf("moon %f at %f", windows[5].MoonIllumination, windows[5].MoonElevation)
+	}
+}
+
+func TestGalacticCenterWindowsLimits(t *testing.T) {
+	// the core does not climb to 20 degrees in London
+	london := Observer{Latitude: 51.5, Longitude: -0.13}
+	from := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	if windows := GalacticCenterWindows(london, from, from.AddDate(0, 0, 7), 20); len(windows) != 0 {
+		t.Fatalf("windows %v", windows)
+	}
+
+	// the core is behind the sun in December
+	from = time.Date(2024, 12, 10, 0, 0, 0, 0, time.UTC)
+	if windows := GalacticCenterWindows(Observer{Latitude: 38.57, Longitude: -109.55}, from, from.AddDate(0, 0, 7), 10); len(windows) != 0 {
+		t.Fatalf("windows %v", windows)
+	}
+
+	// it passes the zenith in the southern winter
+	from = time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	windows := GalacticCenterWindows(Observer{Latitude: -29, Longitude: 21}, from, from.AddDate(0, 0, 3), 10)
+	if len(windows) == 0 {
+		t.Fatal("no windows")
+	}
+	almostEqualFloat(t, windows[0].BestElevation, 90, 0.1)
+}
@@ -0,0 +1,165 @@
+package celestial
+
+import (
+	"math"
+	"time"
+)
+
+// A periodic term of the IAU 1980 theory of nutation. The multiples of the fundamental
+// arguments D, M, M', F and Ω form the argument. The coefficients of the nutation in
+// longitude (sine) and in obliquity (cosine) are in units of 0.0001" with their rate
+// per Julian century.
+type nutationTerm struct {
+	d, m, mp, f, omega int
+	psi, psiT          float64
+	eps, epsT          float64
+}
+
+// The IAU 1980 theory of nutation
+// See Meeus, Astronomical Algorithms, table 22.A
+var nutationTerms = [...]nutationTerm{
+	{0, 0, 0, 0, 1, -171996, -174.2, 92025, 8.9},
+	{-2, 0, 0, 2, 2, -13187, -1.6, 5736, -3.1},
+	{0, 0, 0, 2, 2, -2274, -0.2, 977, -0.5},
+	{0, 0, 0, 0, 2, 2062, 0.2, -895, 0.5},
+	{0, 1, 0, 0, 0, 1426, -3.4, 54, -0.1},
+	{0, 0, 1, 0, 0, 712, 0.1, -7, 0},
+	{-2, 1, 0, 2, 2, -517, 1.2, 224, -0.6},
+	{0, 0, 0, 2, 1, -386, -0.4, 200, 0},
+	{0, 0, 1, 2, 2, -301, 0, 129, -0.1},
+	{-2, -1, 0, 2, 2, 217, -0.5, -95, 0.3},
+	{-2, 0, 1, 0, 0, -158, 0, 0, 0},
+	{-2, 0, 0, 2, 1, 129, 0.1, -70, 0},
+	{0, 0, -1, 2, 2, 123, 0, -53, 0},
+	{2, 0, 0, 0, 0, 63, 0, 0, 0},
+	{0, 0, 1, 0, 1, 63, 0.1, -33, 0},
+	{2, 0, -1, 2, 2, -59, 0, 26, 0},
+	{0, 0, -1, 0, 1, -58, -0.1, 32, 0},
+	{0, 0, 1, 2, 1, -51, 0, 27, 0},
+	{-2, 0, 2, 0, 0, 48, 0, 0, 0},
+	{0, 0, -2, 2, 1, 46, 0, -24, 0},
+	{2, 0, 0, 2, 2, -38, 0, 16, 0},
+	{0, 0, 2, 2, 2, -31, 0, 13, 0},
+	{0, 0, 2, 0, 0, 29, 0, 0, 0},
+	{-2, 0, 1, 2, 2, 29, 0, -12, 0},
+	{0, 0, 0, 2, 0, 26, 0, 0, 0},
+	{-2, 0, 0, 2, 0, -22, 0, 0, 0},
+	{0, 0, -1, 2, 1, 21, 0, -10, 0},
+	{0, 2, 0, 0, 0, 17, -0.1, 0, 0},
+	{2, 0, -1, 0, 1, 16, 0, -8, 0},
+	{-2, 2, 0, 2, 2, -16, 0.1, 7, 0},
+	{0, 1, 0, 0, 1, -15, 0, 9, 0},
+	{-2, 0, 1, 0, 1, -13, 0, 7, 0},
+	{0, -1, 0, 0, 1, -12, 0, 6, 0},
+	{0, 0, 2, -2, 0, 11, 0, 0, 0},
+	{2, 0, -1, 2, 1, -10, 0, 5, 0},
+	{2, 0, 1, 2, 2, -8, 0, 3, 0},
+	{0, 1, 0, 2, 2, 7, 0, -3, 0},
+	{-2, 1, 1, 0, 0, -7, 0, 0, 0},
+	{0, -1, 0, 2, 2, -7, 0, 3, 0},
+	{2, 0, 0, 2, 1, -7, 0, 3, 0},
+	{2, 0, 1, 0, 0, 6, 0, 0, 0},
+	{-2, 0, 2, 2, 2, 6, 0, -3, 0},
+	{-2, 0, 1, 2, 1, 6, 0, -3, 0},
+	{2, 0, -2, 0, 1, -6, 0, 3, 0},
+	{2, 0, 0, 0, 1, -6, 0, 3, 0},
+	{0, -1, 1, 0, 0, 5, 0, 0, 0},
+	{-2, -1, 0, 2, 1, -5, 0, 3, 0},
+	{-2, 0, 0, 0, 1, -5, 0, 3, 0},
+	{0, 0, 2, 2, 1, -5, 0, 3, 0},
+	{-2, 0, 2, 0, 1, 4, 0, 0, 0},
+	{-2, 1, 0, 2, 1, 4, 0, 0, 0},
+	{0, 0, 1, -2, 0, 4, 0, 0, 0},
+	{-1, 0, 1, 0, 0, -4, 0, 0, 0},
+	{-2, 1, 0, 0, 0, -4, 0, 0, 0},
+	{1, 0, 0, 0, 0, -4, 0, 0, 0},
+	{0, 0, 1, 2, 0, 3, 0, 0, 0},
+	{0, 0, -2, 2, 2, -3, 0, 0, 0},
+	{-1, -1, 1, 0, 0, -3, 0, 0, 0},
+	{0, 1, 1, 0, 0, -3, 0, 0, 0},
+	{0, -1, 1, 2, 2, -3, 0, 0, 0},
+	{2, -1, -1, 2, 2, -3, 0, 0, 0},
+	{0, 0, 3, 2, 2, -3, 0, 0, 0},
+	{2, -1, 0, 2, 2, -3, 0, 0, 0},
+}
+
+// Calculate the nutation in longitude and in obliquity with the IAU 1980 theory
+//
+// See Meeus, Astronomical Algorithms, chapter 22
+// Args:
+//
+//	juliancentury: The Julian Century on the TT scale
+//
+// Returns:
+//
+//	The nutation in longitude Δψ and in obliquity Δε in degrees.
+func nutation(juliancentury float64) (float64, float64) {
+	t := juliancentury
+	// mean elongation of the moon, mean anomalies of the sun and the moon, the moon's
+	// argument of latitude and the longitude of its ascending node
+	d := radians(297.85036 + t*(445267.111480+t*(-0.0019142+t/189474)))
+	m := radians(357.52772 + t*(35999.050340+t*(-0.0001603-t/300000)))
+	mp := radians(134.96298 + t*(477198.867398+t*(0.0086972+t/56250)))
+	f := radians(93.27191 + t*(483202.017538+t*(-0.0036825+t/327270)))
+	omega := radians(125.04452 + t*(-1934.136261+t*(0.0020708+t/450000)))
+
+	psi, eps := 0.0, 0.0
+	for _, term := range nutationTerms {
+		argument := float64(term.d)*d + float64(term.m)*m + float64(term.mp)*mp + float64(term.f)*f + float64(term.omega)*omega
+		sin, cos := math.Sincos(argument)
+		psi += (term.psi + term.psiT*t) * sin
+		eps += (term.eps + term.epsT*t) * cos
+	}
+	return psi / 36e6, eps / 36e6
+}
+
+// Calculate the nutation in longitude
+// Returns:
+//
+//	The nutation in degrees.
+func nutation_in_longitude(juliancentury float64) float64 {
+	psi, _ := nutation(juliancentury)
+	return psi
+}
+
+// Calculate the nutation in obliquity
+// Returns:
+//
+//	The nutation in degrees.
+func nutation_in_obliquity(juliancentury float64) float64 {
+	_, eps := nutation(juliancentury)
+	return eps
+}
+
+// Move a position from the mean equator and equinox of date to the true ones
+// Args:
+//
+//	juliancentury: The Julian Century on the TT scale
+//	ra, dec:       The mean right ascension and declination of date in degrees
+//
+// Returns:
+//
+//	The true right ascension and declination of date in degrees.
+func nutate_equatorial(juliancentury, ra, dec float64) (float64, float64) {
+	psi, eps := nutation(juliancentury)
+	obliquity := mean_obliquity_of_ecliptic(juliancentury)
+	longitude, latitude := equatorial_to_ecliptic(ra, dec, obliquity)
+	return ecliptic_to_equatorial(longitude+psi, latitude, obliquity+eps)
+}
+
+// Nutation calculates the nutation of the earth's axis with the IAU 1980 theory.
+// Returns:
+//
+//	The nutation in longitude Δψ and in obliquity Δε in degrees.
+func Nutation(dateandtime time.Time) (float64, float64) {
+	return nutation(jday_to_jcentury(julian_ephemeris_day(dateandtime)))
+}
+
+// TrueObliquity calculates the obliquity of the ecliptic including the nutation, the angle
+// between the true equator of date and the ecliptic.
+// Returns:
+//
+//	The obliquity in degrees.
+func TrueObliquity(dateandtime time.Time) float64 {
+	return obliquity_correction(jday_to_jcentury(julian_ephemeris_day(dateandtime)))
+}
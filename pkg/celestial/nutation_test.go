@@ -0,0 +1,30 @@
+package celestial
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNutation(t *testing.T) {
+	// Meeus, Astronomical Algorithms, example 22.a
+	jc := jday_to_jcentury(2446895.5)
+	psi, eps := nutation(jc)
+	almostEqualFloat(t, psi*3600, -3.788, 0.001)
+	almostEqualFloat(t, eps*3600, 9.443, 0.001)
+	// Meeus uses the IAU 1980 obliquity, which differs from that of IAU 2006 by 0.04"
+	almostEqualFloat(t, mean_obliquity_of_ecliptic(jc), 23+26.0/60+27.407/3600, 0.05/3600)
+	almostEqualFloat(t, obliquity_correction(jc), 23+26.0/60+36.850/3600, 0.05/3600)
+
+	psi, eps = Nutation(time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC))
+	almostEqualFloat(t, psi*3600, -13.9, 0.1)
+	almostEqualFloat(t, eps*3600, -5.8, 0.1)
+	almostEqualFloat(t, TrueObliquity(time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC)), 23.4393-5.8/3600, 0.0001)
+}
+
+func TestNutateEquatorial(t *testing.T) {
+	// Meeus, Astronomical Algorithms, example 23.a
+	jc := jday_to_jcentury(2462088.69)
+	ra, dec := nutate_equatorial(jc, 41.547214, 49.348483)
+	almostEqualFloat(t, (ra-41.547214)*3600, 15.843, 0.01)
+	almostEqualFloat(t, (dec-49.348483)*3600, 6.218, 0.01)
+}
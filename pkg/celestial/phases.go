@@ -0,0 +1,108 @@
+package celestial
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// LunarPhase is one of the four principal phases of the moon.
+type LunarPhase int
+
+const (
+	NewMoon LunarPhase = iota
+	FirstQuarter
+	FullMoon
+	LastQuarter
+)
+
+func (p LunarPhase) String() string {
+	switch p {
+	case NewMoon:
+		return "New Moon"
+	case FirstQuarter:
+		return "First Quarter"
+	case FullMoon:
+		return "Full Moon"
+	case LastQuarter:
+		return "Last Quarter"
+	}
+	return fmt.Sprintf("LunarPhase(%d)", int(p))
+}
+
+// The mean motion of the moon away from the sun in degrees per day
+const synodicRate = 360 / 29.530589
+
+// Calculate how far the moon is ahead of the sun in apparent longitude
+// Returns:
+//
+//	The elongation in longitude in degrees, 0 at new moon and 180 at full moon.
+func moon_sun_elongation(dateandtime time.Time) float64 {
+	jc := jday_to_jcentury(julian_ephemeris_day(dateandtime))
+	moon, _, _ := moon_position(jc)
+	sun, _, _ := sun_apparent_position(jc)
+	return properAngle(moon + nutation_in_longitude(jc) - sun)
+}
+
+// Find the time near a given time at which the moon reaches an elongation from the sun
+// Returns:
+//
+//	The time, to about a second.
+func moon_elongation_time(elongation float64, near time.Time) time.Time {
+	// the moon's speed varies by a fifth around the mean, so steps at the mean speed converge
+	t := near
+	for i := 0; i < 20; i++ {
+		days := angle_difference(elongation, moon_sun_elongation(t)) / synodicRate
+		t = t.Add(time.Duration(days * 24 * float64(time.Hour)))
+		if math.Abs(days) < 1e-6 {
+			break
+		}
+	}
+	return t.Round(time.Second)
+}
+
+// LunarPhaseTime is the instant of a principal phase of the moon.
+type LunarPhaseTime struct {
+	Phase LunarPhase
+	Time  time.Time
+}
+
+// LunarPhases calculates the instants of the new moons, quarters and full moons, at which
+// the apparent longitudes of the moon and the sun differ by a multiple of 90 degrees.
+//
+// The moon follows the series of Meeus, Astronomical Algorithms, chapter 47, which puts the
+// phases within about a minute of the precise ephemerides.
+// Args:
+//
+//	from, to: The time range to search
+//
+// Returns:
+//
+//	The phases in the range in UTC, in order.
+func LunarPhases(from, to time.Time) []LunarPhaseTime {
+	var phases []LunarPhaseTime
+	elongation := moon_sun_elongation(from)
+	phase := LunarPhase(int(elongation/90+1) % 4)
+	near := from.Add(time.Duration((90*float64(int(elongation/90)+1) - elongation) / synodicRate * 24 * float64(time.Hour)))
+	for {
+		t := moon_elongation_time(float64(phase)*90, near)
+		if t.After(to) {
+			return phases
+		}
+		if !t.Before(from) {
+			phases = append(phases, LunarPhaseTime{Phase: phase, Time: t.UTC()})
+		}
+		phase = (phase + 1) % 4
+		near = t.Add(time.Duration(90 / synodicRate * 24 * float64(time.Hour)))
+	}
+}
+
+// NextLunarPhase finds the first instant of a phase of the moon at or after a time.
+func NextLunarPhase(phase LunarPhase, after time.Time) time.Time {
+	elongation := angle_difference(float64(phase)*90, moon_sun_elongation(after))
+	t := moon_elongation_time(float64(phase)*90, after.Add(time.Duration(elongation/synodicRate*24*float64(time.Hour))))
+	if t.Before(after) {
+		t = moon_elongation_time(float64(phase)*90, t.Add(time.Duration(360/synodicRate*24*float64(time.Hour))))
+	}
+	return t.UTC()
+}
@@ -0,0 +1,66 @@
+package celestial
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLunarPhases(t *testing.T) {
+	// phases of 2024 from the USNO
+	var tests = []LunarPhaseTime{
+		{FullMoon, time.Date(2024, 1, 25, 17, 54, 0, 0, time.UTC)},
+		{NewMoon, time.Date(2024, 4, 8, 18, 21, 0, 0, time.UTC)},
+		{FirstQuarter, time.Date(2024, 8, 12, 15, 19, 0, 0, time.UTC)},
+		{FullMoon, time.Date(2024, 10, 17, 11, 26, 0, 0, time.UTC)},
+		{LastQuarter, time.Date(2024, 11, 23, 1, 28, 0, 0, time.UTC)},
+		{NewMoon, time.Date(2024, 12, 30, 22, 27, 0, 0, time.UTC)},
+	}
+
+	phases := LunarPhases(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	if len(phases) != 50 {
+		t.Fatalf("got %d phases", len(phases))
+	}
+	for i := 1; i < len(phases); i++ {
+		if phases[i].Phase != (phases[i-1].Phase+1)%4 || !phases[i].Time.After(phases[i-1].Time) {
+			t.Fatalf("%v follows %v", phases[i], phases[i-1])
+		}
+	}
+	for _, test := range tests {
+		t.Run(test.Time.String(), func(t *testing.T) {
+			for _, phase := range phases {
+				if phase.Phase == test.Phase && diff(phase.Time, test.Time) < 12*time.Hour {
+					almostEqualTime(t, phase.Time, test.Time, time.Minute)
+					return
+				}
+			}
+			t.Fatalf("%v not found", test.Phase)
+		})
+	}
+}
+
+func TestNextLunarPhase(t *testing.T) {
+	var tests = []struct {
+		phase LunarPhase
+		after time.Time
+		want  time.Time
+	}{
+		// See Meeus, Astronomical Algorithms, examples 49.a and 49.b, converted to UT
+		{NewMoon, time.Date(1977, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(1977, 2, 18, 3, 36, 54, 0, time.UTC)},
+		{LastQuarter, time.Date(2044, 1, 10, 0, 0, 0, 0, time.UTC), time.Date(2044, 1, 21, 23, 47, 0, 0, time.UTC)},
+		// a phase right at the start is found
+		{FullMoon, time.Date(2024, 10, 17, 11, 26, 26, 0, time.UTC), time.Date(2024, 10, 17, 11, 26, 26, 0, time.UTC)},
+		{FullMoon, time.Date(2024, 10, 17, 11, 30, 0, 0, time.UTC), time.Date(2024, 11, 15, 21, 28, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.phase.String(), func(t *testing.T) {
+			almostEqualTime(t, NextLunarPhase(test.phase, test.after), test.want, time.Minute)
+		})
+	}
+}
+
+func TestLunarPhaseString(t *testing.T) {
+	if FirstQuarter.String() != "First Quarter" || LunarPhase(7).String() != "LunarPhase(7)" {
+		t.Fatal(FirstQuarter.String(), LunarPhase(7).String())
+	}
+}
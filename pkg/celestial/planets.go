@@ -0,0 +1,240 @@
+package celestial
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Planet identifies one of the major planets other than the earth.
+type Planet int
+
+const (
+	Mercury Planet = iota
+	Venus
+	Mars
+	Jupiter
+	Saturn
+	Uranus
+	Neptune
+)
+
+var planetNames = [...]string{
+	Mercury: "Mercury",
+	Venus:   "Venus",
+	Mars:    "Mars",
+	Jupiter: "Jupiter",
+	Saturn:  "Saturn",
+	Uranus:  "Uranus",
+	Neptune: "Neptune",
+}
+
+func (p Planet) String() string {
+	if p < 0 || int(p) >= len(planetNames) {
+		return fmt.Sprintf("Planet(%d)", int(p))
+	}
+	return planetNames[p]
+}
+
+// Calculate the heliocentric position of a planet from VSOP87
+// Args:
+//
+//	planet:        The planet to calculate for
+//	juliancentury: The Julian Century on the TT scale
+//
+// Returns:
+//
+//	The longitude and latitude in degrees referred to the mean ecliptic and equinox
+//	of date and the distance in AU.
+func planet_heliocentric(planet Planet, juliancentury float64) (float64, float64, float64) {
+	terms := planetTerms[planet]
+	millennium := juliancentury / 10
+	longitude := properAngle(degrees(vsop87_sum(terms.longitude, millennium)))
+	latitude := degrees(vsop87_sum(terms.latitude, millennium))
+	return longitude, latitude, vsop87_sum(terms.radius, millennium)
+}
+
+// Convert heliocentric ecliptic coordinates to rectangular ones
+func ecliptic_rectangular(longitude, latitude, distance float64) (float64, float64, float64) {
+	sinl, cosl := math.Sincos(radians(longitude))
+	sinb, cosb := math.Sincos(radians(latitude))
+	return distance * cosb * cosl, distance * cosb * sinl, distance * sinb
+}
+
+// Light travel time for one AU in days
+const lightTimePerAU = 0.0057755183
+
+// PlanetPosition holds the position and appearance of a planet at an instant.
+type PlanetPosition struct {
+	// HeliocentricLongitude, HeliocentricLatitude and HeliocentricDistance are the position
+	// relative to the sun, in degrees and AU, at the time the light left the planet
+	HeliocentricLongitude float64
+	HeliocentricLatitude  float64
+	HeliocentricDistance  float64
+	// Longitude and Latitude are the apparent geocentric ecliptic coordinates of date in degrees
+	Longitude float64
+	Latitude  float64
+	// Distance is the distance from the earth in AU
+	Distance       float64
+	RightAscension float64
+	Declination    float64
+	// Elongation is the angle between the sun and the planet as seen from the earth in degrees
+	Elongation float64
+	// PhaseAngle is the angle between the sun and the earth as seen from the planet in degrees
+	PhaseAngle float64
+	// Illumination is the illuminated fraction of the disk, between 0 and 1
+	Illumination float64
+	// Magnitude is the apparent visual magnitude
+	Magnitude float64
+}
+
+// Calculate the tilt of Saturn's rings towards the earth and the difference between the
+// Saturnicentric longitudes of the sun and the earth, both in degrees
+//
+// See Meeus, Astronomical Algorithms, chapter 45
+func saturn_ring_tilt(juliancentury, l, b, r, longitude, latitude float64) (float64, float64) {
+	t := juliancentury
+	i := radians(28.075216 - 0.012998*t + 0.000004*t*t)
+	node := 169.508470 + 1.394681*t + 0.000412*t*t
+
+	sini, cosi := math.Sincos(i)
+	sinb, cosb := math.Sincos(radians(latitude))
+	sinl, cosl := math.Sincos(radians(longitude - node))
+	tilt := degrees(math.Asin(sini*cosb*sinl - cosi*sinb))
+	u2 := degrees(math.Atan2(sini*sinb+cosi*cosb*sinl, cosb*cosl))
+
+	n := 113.6655 + 0.8771*t
+	lp := radians(l - 0.01759/r - node)
+	bp := radians(b - 0.000764*math.Cos(radians(l-n))/r)
+	sinbp, cosbp := math.Sincos(bp)
+	u1 := degrees(math.Atan2(sini*sinbp+cosi*cosbp*math.Sin(lp), cosbp*math.Cos(lp)))
+
+	return tilt, math.Abs(angle_difference(u1, u2))
+}
+
+// Calculate the apparent visual magnitude of a planet
+//
+// See Meeus, Astronomical Algorithms, chapter 41
+// Args:
+//
+//	r, distance: The distances of the planet from the sun and the earth in AU
+//	i:           The phase angle in degrees
+func planet_magnitude(planet Planet, r, distance, i, ringTilt, deltaU float64) float64 {
+	base := 5 * math.Log10(r*distance)
+	switch planet {
+	case Mercury:
+		return -0.42 + base + 0.0380*i - 0.000273*i*i + 0.000002*i*i*i
+	case Venus:
+		return -4.40 + base + 0.0009*i + 0.000239*i*i - 0.00000065*i*i*i
+	case Mars:
+		return -1.52 + base + 0.016*i
+	case Jupiter:
+		return -9.40 + base + 0.005*i
+	case Saturn:
+		sinb := math.Sin(radians(math.Abs(ringTilt)))
+		return -8.88 + base + 0.044*deltaU - 2.60*sinb + 1.25*sinb*sinb
+	case Uranus:
+		return -7.19 + base
+	default:
+		return -6.87 + base
+	}
+}
+
+// Calculate the geocentric position of a planet
+//
+// See Meeus, Astronomical Algorithms, chapter 33
+func planet_position(planet Planet, dateandtime time.Time) PlanetPosition {
+	jc := jday_to_jcentury(julian_ephemeris_day(dateandtime))
+	earth, velocity := earth_state(jc)
+	geocentric, helio := planet_geocentric(planet, jc, earth, true)
+	l, b, r := helio.spherical()
+	distance := geocentric.length()
+	er := earth.length()
+
+	longitude, latitude, _ := Apparent.direction(geocentric, helio, earth, velocity).spherical()
+	longitude = properAngle(longitude + nutation_in_longitude(jc))
+	ra, dec := ecliptic_to_equatorial(longitude, latitude, obliquity_correction(jc))
+
+	phase := degrees(math.Acos(clamp((r*r+distance*distance-er*er)/(2*r*distance), -1, 1)))
+	elongation := degrees(math.Acos(clamp((er*er+distance*distance-r*r)/(2*er*distance), -1, 1)))
+
+	var tilt, deltaU float64
+	if planet == Saturn {
+		tilt, deltaU = saturn_ring_tilt(jc, l, b, r, longitude, latitude)
+	}
+
+	return PlanetPosition{
+		HeliocentricLongitude: l,
+		HeliocentricLatitude:  b,
+		HeliocentricDistance:  r,
+		Longitude:             longitude,
+		Latitude:              latitude,
+		Distance:              distance,
+		RightAscension:        ra,
+		Declination:           dec,
+		Elongation:            elongation,
+		PhaseAngle:            phase,
+		Illumination:          (1 + math.Cos(radians(phase))) / 2,
+		Magnitude:             planet_magnitude(planet, r, distance, phase, tilt, deltaU),
+	}
+}
+
+// PlanetCoordinates calculates the position and appearance of a planet.
+//
+// The earth and the planets follow the VSOP87 theory truncated as in Meeus, Astronomical
+// Algorithms, appendix III, which is accurate to a few arc seconds within a few centuries
+// of J2000.
+// Args:
+//
+//	planet:      The planet to calculate for
+//	dateandtime: The date and time to calculate for
+//
+// Returns:
+//
+//	The heliocentric and apparent geocentric coordinates, the elongation, phase and magnitude.
+func PlanetCoordinates(planet Planet, dateandtime time.Time) PlanetPosition {
+	return planet_position(planet, dateandtime)
+}
+
+// PlanetZenithAndAzimuth calculates the topocentric zenith and azimuth angles of a planet.
+// Args:
+//
+//	planet:          The planet to calculate for
+//	observer:        Observer to calculate for
+//	dateandtime:     The date and time for which to calculate the angles.
+//	with_refraction: If True adjust zenith to take refraction into account
+//
+// Returns:
+//
+//	The zenith angle and the azimuth clockwise from North in degrees.
+func PlanetZenithAndAzimuth(planet Planet, observer Observer, dateandtime time.Time, with_refraction bool) (float64, float64) {
+	position := planet_position(planet, dateandtime)
+
+	lst := greenwich_apparent_sidereal_time(julian_datetime(dateandtime)) + observer.Longitude
+	ra, dec, _ := equatorial_to_topocentric(observer, lst, position.RightAscension, position.Declination, position.Distance*AstronomicalUnit)
+
+	elevation, azimuth := equatorial_to_horizontal(observer.Latitude, lst-ra, dec)
+	zenith := 90 - elevation
+	if with_refraction {
+		zenith -= refraction_at_zenith(zenith)
+	}
+	return zenith, azimuth
+}
+
+// PlanetElevation calculates a planet's angle of elevation as seen by the observer.
+// Returns:
+//
+//	The elevation angle in degrees above the horizon.
+func PlanetElevation(planet Planet, observer Observer, dateandtime time.Time, with_refraction bool) float64 {
+	zenith, _ := PlanetZenithAndAzimuth(planet, observer, dateandtime, with_refraction)
+	return 90 - zenith
+}
+
+// PlanetAzimuth calculates the azimuth angle of a planet as seen by the observer.
+// Returns:
+//
+//	The azimuth angle in degrees clockwise from North.
+func PlanetAzimuth(planet Planet, observer Observer, dateandtime time.Time) float64 {
+	_, azimuth := PlanetZenithAndAzimuth(planet, observer, dateandtime, true)
+	return azimuth
+}
@@ -0,0 +1,99 @@
+package celestial
+
+import (
+	"testing"
+	"time"
+)
+
+// The time on the UT scale of an instant given on the TT scale
+func terrestrialTime(year int, month time.Month, day int) time.Time {
+	tt := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	return tt.Add(-time.Duration(delta_t(tt) * float64(time.Second)))
+}
+
+func TestPlanetString(t *testing.T) {
+	if Jupiter.String() != "Jupiter" {
+		t.Errorf("got %v, want Jupiter", Jupiter)
+	}
+	if Planet(12).String() != "Planet(12)" {
+		t.Errorf("got %v, want Planet(12)", Planet(12))
+	}
+}
+
+func TestPlanetCoordinates(t *testing.T) {
+	// Meeus, Astronomical Algorithms, examples 33.a and 41.a
+	venus := PlanetCoordinates(Venus, terrestrialTime(1992, 12, 20))
+	almostEqualFloat(t, venus.HeliocentricLongitude, 26.10588, 0.00001)
+	almostEqualFloat(t, venus.HeliocentricLatitude, -2.62102, 0.00001)
+	almostEqualFloat(t, venus.HeliocentricDistance, 0.724604, 0.000001)
+	almostEqualFloat(t, venus.Longitude, 313.08102+(-14.868+16.749)/3600, 0.0002)
+	almostEqualFloat(t, venus.RightAscension, 316.172725, 0.0002)
+	almostEqualFloat(t, venus.Declination, -18.888011, 0.0002)
+	almostEqualFloat(t, venus.Distance, 0.910947, 0.000001)
+	almostEqualFloat(t, venus.PhaseAngle, 72.96, 0.02)
+	almostEqualFloat(t, venus.Illumination, 0.647, 0.001)
+	almostEqualFloat(t, venus.Magnitude, -4.2, 0.05)
+}
+
+func TestSaturnRingTilt(t *testing.T) {
+	// Meeus, Astronomical Algorithms, example 45.a
+	dateandtime := terrestrialTime(1992, 12, 16)
+	saturn := PlanetCoordinates(Saturn, dateandtime)
+	jc := jday_to_jcentury(julian_ephemeris_day(dateandtime))
+	tilt, deltaU := saturn_ring_tilt(jc, saturn.HeliocentricLongitude, saturn.HeliocentricLatitude,
+		saturn.HeliocentricDistance, saturn.Longitude, saturn.Latitude)
+	almostEqualFloat(t, tilt, 16.442, 0.001)
+	almostEqualFloat(t, deltaU, 4.198, 0.001)
+}
+
+func TestPlanetZenithAndAzimuth(t *testing.T) {
+	// a planet stands in the zenith where the local sidereal time equals its right
+	// ascension and the latitude its declination
+	dateandtime := time.Date(2024, 12, 7, 22, 0, 0, 0, time.UTC)
+	for planet := Mercury; planet <= Neptune; planet++ {
+		position := PlanetCoordinates(planet, dateandtime)
+		gast := greenwich_apparent_sidereal_time(julian_datetime(dateandtime))
+		observer := Observer{Latitude: position.Declination, Longitude: angle_difference(position.RightAscension-gast, 0)}
+
+		zenith, _ := PlanetZenithAndAzimuth(planet, observer, dateandtime, false)
+		almostEqualFloat(t, zenith, 0, 0.01)
+		almostEqualFloat(t, PlanetElevation(planet, observer, dateandtime, true), 90, 0.01)
+	}
+}
+
+func TestPlanetReferenceEvents(t *testing.T) {
+	// greatest transit of Mercury and Venus and the great conjunction of Jupiter and
+	// Saturn, as published by NASA, which check the series of the inner planets against
+	// the earth's and those of Jupiter and Saturn against each other
+	tests := []struct {
+		name           string
+		a, b           Body
+		from           time.Time
+		want           time.Time
+		wantSeparation float64
+	}{
+		{a: Sun, b: Mercury, from: time.Date(2016, 5, 9, 0, 0, 0, 0, time.UTC), want: time.Date(2016, 5, 9, 14, 57, 26, 0, time.UTC), wantSeparation: 318.5 / 3600},
+		{a: Sun, b: Mercury, from: time.Date(2019, 11, 11, 0, 0, 0, 0, time.UTC), want: time.Date(2019, 11, 11, 15, 19, 48, 0, time.UTC), wantSeparation: 75.9 / 3600},
+		{a: Sun, b: Venus, from: time.Date(2004, 6, 8, 0, 0, 0, 0, time.UTC), want: time.Date(2004, 6, 8, 8, 19, 44, 0, time.UTC), wantSeparation: 626.9 / 3600},
+		{a: Sun, b: Venus, from: time.Date(2012, 6, 5, 12, 0, 0, 0, time.UTC), want: time.Date(2012, 6, 6, 1, 29, 36, 0, time.UTC), wantSeparation: 554.4 / 3600},
+		{a: Jupiter, b: Saturn, from: time.Date(2020, 12, 15, 0, 0, 0, 0, time.UTC), want: time.Date(2020, 12, 21, 18, 20, 0, 0, time.UTC), wantSeparation: 6.1 / 60},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conjunctions := Conjunctions(tt.a, tt.b, tt.from, tt.from.AddDate(0, 0, 14), 1)
+			if len(conjunctions) != 1 {
+				t.Fatalf("got %d conjunctions, want 1", len(conjunctions))
+			}
+			almostEqualTime(t, conjunctions[0].Time, tt.want, 3*time.Minute)
+			almostEqualFloat(t, conjunctions[0].Separation, tt.wantSeparation, 2.0/3600)
+		})
+	}
+
+	// closest approach of Mars on 2003 August 27 at 9:51 UT
+	distance := func(t time.Time) float64 {
+		return PlanetCoordinates(Mars, t).Distance
+	}
+	closest := minimize_time(distance, time.Date(2003, 8, 20, 0, 0, 0, 0, time.UTC), time.Date(2003, 9, 3, 0, 0, 0, 0, time.UTC), time.Minute)
+	almostEqualTime(t, closest, time.Date(2003, 8, 27, 9, 51, 0, 0, time.UTC), 5*time.Minute)
+	almostEqualFloat(t, distance(closest), 0.372719, 0.00002)
+}
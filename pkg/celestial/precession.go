@@ -0,0 +1,95 @@
+package celestial
+
+import (
+	"math"
+	"time"
+)
+
+// Calculate the equatorial precession angles of the IAU 2006 precession
+//
+// See Capitaine et al., Astronomy & Astrophysics 412, 567 (2003), and the IERS
+// Conventions (2010), equation 5.40
+// Args:
+//
+//	juliancentury: The Julian Century on the TT scale
+//
+// Returns:
+//
+//	The angles ζ, z and θ in degrees that precess a position from J2000.0 to the date.
+func precession_angles(juliancentury float64) (float64, float64, float64) {
+	t := juliancentury
+	zeta := 2.650545 + t*(2306.083227+t*(0.2988499+t*(0.01801828+t*(-0.000005971-t*0.0000003173))))
+	z := -2.650545 + t*(2306.077181+t*(1.0927348+t*(0.01826837+t*(-0.000028596-t*0.0000002904))))
+	theta := t * (2004.191903 + t*(-0.4294934+t*(-0.04182264+t*(-0.000007089-t*0.0000001274))))
+	return zeta / 3600, z / 3600, theta / 3600
+}
+
+// Precess a position from the mean equator and equinox of J2000.0 to those of date
+//
+// See Meeus, Astronomical Algorithms, chapter 21
+// Args:
+//
+//	juliancentury: The Julian Century on the TT scale
+//	ra, dec:       The right ascension and declination of J2000.0 in degrees
+//
+// Returns:
+//
+//	The mean right ascension and declination of date in degrees.
+func precess_equatorial_from_j2000(juliancentury, ra, dec float64) (float64, float64) {
+	zeta, z, theta := precession_angles(juliancentury)
+
+	sina, cosa := math.Sincos(radians(ra + zeta))
+	sind, cosd := math.Sincos(radians(dec))
+	sint, cost := math.Sincos(radians(theta))
+
+	a := cosd * sina
+	b := cost*cosd*cosa - sint*sind
+	c := sint*cosd*cosa + cost*sind
+	return properAngle(degrees(math.Atan2(a, b)) + z), degrees(math.Asin(clamp(c, -1, 1)))
+}
+
+// PrecessFromJ2000 precesses a catalogue position of epoch J2000.0 with the IAU 2006
+// precession.
+// Args:
+//
+//	ra, dec:     The right ascension and declination of J2000.0 in degrees
+//	dateandtime: The date and time to precess to
+//
+// Returns:
+//
+//	The right ascension and declination referred to the mean equator and equinox of date.
+func PrecessFromJ2000(ra, dec float64, dateandtime time.Time) (float64, float64) {
+	return precess_equatorial_from_j2000(jday_to_jcentury(julian_ephemeris_day(dateandtime)), ra, dec)
+}
+
+// J2000ToTrueOfDate converts a catalogue position of epoch J2000.0 to the true equator and
+// equinox of date by applying the IAU 2006 precession and the IAU 1980 nutation.
+// Args:
+//
+//	ra, dec:     The right ascension and declination of J2000.0 in degrees
+//	dateandtime: The date and time to convert to
+//
+// Returns:
+//
+//	The right ascension and declination referred to the true equator and equinox of date.
+//	They do not include the aberration or the proper motion, see Apparent for those.
+func J2000ToTrueOfDate(ra, dec float64, dateandtime time.Time) (float64, float64) {
+	jc := jday_to_jcentury(julian_ephemeris_day(dateandtime))
+	ra, dec = precess_equatorial_from_j2000(jc, ra, dec)
+	return nutate_equatorial(jc, ra, dec)
+}
+
+// Precess a position from the mean equator and equinox of date back to those of J2000.0,
+// the inverse of precess_equatorial_from_j2000
+func precess_equatorial_to_j2000(juliancentury, ra, dec float64) (float64, float64) {
+	zeta, z, theta := precession_angles(juliancentury)
+
+	sina, cosa := math.Sincos(radians(ra - z))
+	sind, cosd := math.Sincos(radians(dec))
+	sint, cost := math.Sincos(radians(theta))
+
+	a := cosd * sina
+	b := cost*cosd*cosa + sint*sind
+	c := -sint*cosd*cosa + cost*sind
+	return properAngle(degrees(math.Atan2(a, b)) - zeta), degrees(math.Asin(clamp(c, -1, 1)))
+}
@@ -0,0 +1,44 @@
+package celestial
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPrecessionAngles(t *testing.T) {
+	zeta, z, theta := precession_angles(0)
+	almostEqualFloat(t, zeta*3600, 2.650545, 1e-9)
+	almostEqualFloat(t, z*3600, -2.650545, 1e-9)
+	almostEqualFloat(t, theta, 0, 1e-12)
+
+	// Meeus, Astronomical Algorithms, example 21.b, with the IAU 1976 angles
+	zeta, z, theta = precession_angles(jday_to_jcentury(2462088.69))
+	almostEqualFloat(t, zeta, 665.774/3600, 0.001)
+	almostEqualFloat(t, z, 665.840/3600, 0.001)
+	almostEqualFloat(t, theta, 578.554/3600, 0.001)
+}
+
+func TestPrecessEquatorialFromJ2000(t *testing.T) {
+	// Meeus, Astronomical Algorithms, example 21.b. The IAU 2006 precession in right
+	// ascension is slower than that of IAU 1976 by about 0.3" per century.
+	ra, dec := precess_equatorial_from_j2000(jday_to_jcentury(2462088.69), 41.054063, 49.227750)
+	almostEqualFloat(t, ra, 41.547214, 0.00004)
+	almostEqualFloat(t, dec, 49.348483, 0.00002)
+
+	ra, dec = precess_equatorial_from_j2000(0, 123.4, -56.7)
+	almostEqualFloat(t, ra, 123.4, 1e-9)
+	almostEqualFloat(t, dec, -56.7, 1e-9)
+}
+
+func TestJ2000ToTrueOfDate(t *testing.T) {
+	// Meeus, Astronomical Algorithms, examples 21.b and 23.a without the aberration
+	dateandtime := jday_to_datetime(2462088.69)
+	dateandtime = dateandtime.Add(-time.Duration(delta_t(dateandtime) * float64(time.Second)))
+	ra, dec := J2000ToTrueOfDate(41.054063, 49.227750, dateandtime)
+	almostEqualFloat(t, ra, 41.547214+15.843/3600, 0.00004)
+	almostEqualFloat(t, dec, 49.348483+6.218/3600, 0.00002)
+
+	mra, mdec := PrecessFromJ2000(41.054063, 49.227750, dateandtime)
+	almostEqualFloat(t, mra, 41.547214, 0.00004)
+	almostEqualFloat(t, mdec, 49.348483, 0.00002)
+}
@@ -0,0 +1,263 @@
+package celestial
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+)
+
+// Standard altitudes of the centre of a body at rising and setting in degrees. They include
+// the refraction at the horizon, the sun's semi-diameter and the moon's mean parallax.
+const (
+	StandardAltitudeSun  = -0.8333
+	StandardAltitudeMoon = 0.125
+	StandardAltitudeStar = -0.5667
+)
+
+var (
+	ErrNeverRises = errors.New("body is always below the horizon on this day, at this location")
+	ErrNeverSets  = errors.New("body is always above the horizon on this day, at this location")
+)
+
+// Body is anything with a position on the sky that rises and sets. Implement it to use
+// RiseTransitSet with other ephemerides.
+type Body interface {
+	// Equatorial returns the apparent geocentric right ascension and declination in degrees
+	Equatorial(dateandtime time.Time) (float64, float64)
+	// StandardAltitude returns the geocentric altitude in degrees of the body at rising and setting
+	StandardAltitude() float64
+}
+
+// SunBody is the sun as a Body.
+type SunBody struct{}
+
+// MoonBody is the moon as a Body.
+type MoonBody struct{}
+
+var (
+	Sun  = SunBody{}
+	Moon = MoonBody{}
+)
+
+func (SunBody) Equatorial(dateandtime time.Time) (float64, float64) {
+	place := Apparent.Sun(dateandtime)
+	return place.RightAscension, place.Declination
+}
+
+func (SunBody) StandardAltitude() float64 {
+	return StandardAltitudeSun
+}
+
+func (MoonBody) Equatorial(dateandtime time.Time) (float64, float64) {
+	ra, dec, _ := moon_equatorial(dateandtime)
+	return ra, dec
+}
+
+func (MoonBody) StandardAltitude() float64 {
+	return StandardAltitudeMoon
+}
+
+func (p Planet) Equatorial(dateandtime time.Time) (float64, float64) {
+	position := planet_position(p, dateandtime)
+	return position.RightAscension, position.Declination
+}
+
+func (Planet) StandardAltitude() float64 {
+	return StandardAltitudeStar
+}
+
+// Star is a fixed star with its equatorial coordinates of date.
+type Star struct {
+	RightAscension float64
+	Declination    float64
+}
+
+func (s Star) Equatorial(time.Time) (float64, float64) {
+	return s.RightAscension, s.Declination
+}
+
+func (Star) StandardAltitude() float64 {
+	return StandardAltitudeStar
+}
+
+// RiseTransitSetTimes holds the events of a body on one day. Events that do not happen
+// on the day are zero times.
+type RiseTransitSetTimes struct {
+	Rise    time.Time
+	Transit time.Time
+	Set     time.Time
+	// TransitElevation is the geocentric elevation of the body at its transit in degrees
+	TransitElevation float64
+}
+
+// Degrees of hour angle per day, the rotation of the earth against the stars
+const siderealRate = 360.985647
+
+// The local hour angle of a body in the range (-180, 180], positive west of the meridian
+func body_hour_angle(body Body, observer Observer, dateandtime time.Time) (float64, float64) {
+	ra, dec := body.Equatorial(dateandtime)
+	lst := greenwich_apparent_sidereal_time(julian_datetime(dateandtime)) + observer.Longitude
+	return angle_difference(lst-ra, 0), dec
+}
+
+// Refine the time at which a body reaches a target hour angle, starting from a guess. The
+// body's position is recalculated at every step so that fast movers like the moon converge.
+// The target may depend on the declination. It returns false if the target does not exist.
+func refine_hour_angle(body Body, observer Observer, guess time.Time, target func(dec float64) (float64, bool)) (time.Time, bool) {
+	t := guess
+	for i := 0; i < 20; i++ {
+		h, dec := body_hour_angle(body, observer, t)
+		goal, ok := target(dec)
+		if !ok {
+			return time.Time{}, false
+		}
+		correction := time.Duration(-angle_difference(h, goal) / siderealRate * 24 * float64(time.Hour))
+		t = t.Add(correction)
+		if correction.Abs() < 500*time.Millisecond {
+			return t.Round(time.Second), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// The target hour angle at which a body of a declination reaches an altitude, with sign -1
+// for the rising in the east and 1 for the setting in the west. It returns false if the
+// body does not reach the altitude.
+func altitude_hour_angle(latitude, altitude, sign float64) func(dec float64) (float64, bool) {
+	return func(dec float64) (float64, bool) {
+		sinphi, cosphi := math.Sincos(radians(latitude))
+		sindec, cosdec := math.Sincos(radians(dec))
+		cosh0 := (math.Sin(radians(altitude)) - sinphi*sindec) / (cosphi * cosdec)
+		if cosh0 < -1 || cosh0 > 1 {
+			return 0, false
+		}
+		return sign * degrees(math.Acos(cosh0)), true
+	}
+}
+
+// Find the first time within a day when a body reaches a target hour angle.
+func first_in_day(body Body, observer Observer, start, end time.Time, target func(dec float64) (float64, bool)) (time.Time, bool) {
+	var times []time.Time
+	anyTarget := false
+	for guess := start; !guess.After(end); guess = guess.Add(6 * time.Hour) {
+		t, ok := refine_hour_angle(body, observer, guess, target)
+		anyTarget = anyTarget || ok
+		if ok && !t.Before(start) && t.Before(end) {
+			times = append(times, t)
+		}
+	}
+	if len(times) == 0 {
+		return time.Time{}, anyTarget
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+	return times[0], true
+}
+
+// RiseTransitSet calculates when a body rises, crosses the meridian and sets on a day.
+//
+// The times are those of the geocentric body reaching its standard altitude, lowered by the
+// dip of the horizon for an elevated observer, and of its upper transit.
+// See Meeus, Astronomical Algorithms, chapter 15
+// Args:
+//
+//	body:     The body to calculate for, e.g. Sun, Moon, Jupiter or a Star
+//	observer: Observer to calculate for
+//	date:     Date to calculate for. The day is taken in the date's timezone.
+//
+// Returns:
+//
+//	The times in the date's timezone. The moon skips a rising or setting about once a month,
+//	which leaves that event zero. ErrNeverRises or ErrNeverSets is returned together with
+//	the transit when the body does not reach its standard altitude at all.
+func RiseTransitSet(body Body, observer Observer, date time.Time) (RiseTransitSetTimes, error) {
+	latitude := clamp(observer.Latitude, -89.8, 89.8)
+	h0 := body.StandardAltitude() - adjust_to_horizon(observer.Elevation)
+
+	start := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	end := start.AddDate(0, 0, 1)
+
+	var times RiseTransitSetTimes
+	transit, _ := first_in_day(body, observer, start, end, func(float64) (float64, bool) { return 0, true })
+	if !transit.IsZero() {
+		times.Transit = transit.In(date.Location())
+		_, dec := body.Equatorial(transit)
+		times.TransitElevation = 90 - math.Abs(latitude-dec)
+	}
+
+	rise, ok := first_in_day(body, observer, start, end, altitude_hour_angle(latitude, h0, -1))
+	if !ok {
+		_, dec := body.Equatorial(start.Add(12 * time.Hour))
+		if 90-math.Abs(latitude-dec) < h0 {
+			return times, ErrNeverRises
+		}
+		return times, ErrNeverSets
+	}
+	set, _ := first_in_day(body, observer, start, end, altitude_hour_angle(latitude, h0, 1))
+
+	if !rise.IsZero() {
+		times.Rise = rise.In(date.Location())
+	}
+	if !set.IsZero() {
+		times.Set = set.In(date.Location())
+	}
+	return times, nil
+}
+
+// A body that counts as risen when it reaches a chosen altitude instead of its standard altitude
+type altitudeBody struct {
+	Body
+	altitude float64
+}
+
+func (b altitudeBody) StandardAltitude() float64 {
+	return b.altitude
+}
+
+// Calculate the geocentric elevation and azimuth of a body in degrees, without refraction
+func body_horizontal(body Body, observer Observer, dateandtime time.Time) (float64, float64) {
+	ra, dec := body.Equatorial(dateandtime)
+	lst := greenwich_apparent_sidereal_time(julian_datetime(dateandtime)) + observer.Longitude
+	return equatorial_to_horizontal(observer.Latitude, lst-ra, dec)
+}
+
+// An interval of time
+type interval struct {
+	start time.Time
+	end   time.Time
+}
+
+// Find the intervals between start and end in which a body is above its standard altitude,
+// from its rising and setting times on the days in between.
+func body_above(body Body, observer Observer, start, end time.Time) []interval {
+	type event struct {
+		time time.Time
+		up   bool
+	}
+	var events []event
+	first := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
+	for day := first; day.Before(end); day = day.AddDate(0, 0, 1) {
+		times, err := RiseTransitSet(body, observer, day)
+		if err != nil {
+			continue
+		}
+		for _, e := range []event{{times.Rise, true}, {times.Set, false}} {
+			if !e.time.IsZero() && e.time.After(start) && e.time.Before(end) {
+				events = append(events, e)
+			}
+		}
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].time.Before(events[j].time) })
+	events = append(events, event{end, false})
+
+	var intervals []interval
+	elevation, _ := body_horizontal(body, observer, start)
+	segment, up := start, elevation > body.StandardAltitude()-adjust_to_horizon(observer.Elevation)
+	for _, e := range events {
+		if up && segment.Before(e.time) {
+			intervals = append(intervals, interval{segment, e.time})
+		}
+		segment, up = e.time, e.up
+	}
+	return intervals
+}
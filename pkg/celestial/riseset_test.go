@@ -0,0 +1,99 @@
+package celestial
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestRiseTransitSetSun(t *testing.T) {
+	var tests = []time.Time{
+		time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 6, 21, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 12, 21, 0, 0, 0, 0, time.UTC),
+	}
+
+	for _, date := range tests {
+		t.Run(date.String(), func(t *testing.T) {
+			times, err := RiseTransitSet(Sun, london, date)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sunrise, _ := Sunrise(london, date)
+			sunset, _ := Sunset(london, date)
+			almostEqualTime(t, times.Rise, sunrise, time.Minute)
+			almostEqualTime(t, times.Transit, Noon(london, date), time.Minute)
+			almostEqualTime(t, times.Set, sunset, time.Minute)
+		})
+	}
+}
+
+func TestRiseTransitSetVenus(t *testing.T) {
+	// Meeus, Astronomical Algorithms, example 15.a
+	boston := Observer{Latitude: 42.3333, Longitude: -71.0833}
+	times, err := RiseTransitSet(Venus, boston, time.Date(1988, 3, 20, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	almostEqualTime(t, times.Rise, time.Date(1988, 3, 20, 12, 25, 0, 0, time.UTC), time.Minute)
+	almostEqualTime(t, times.Transit, time.Date(1988, 3, 20, 19, 41, 0, 0, time.UTC), time.Minute)
+	almostEqualTime(t, times.Set, time.Date(1988, 3, 20, 2, 55, 0, 0, time.UTC), time.Minute)
+}
+
+func TestRiseTransitSetMoon(t *testing.T) {
+	missing := 0
+	for day := 1; day <= 31; day++ {
+		date := time.Date(2024, 10, day, 0, 0, 0, 0, time.UTC)
+		times, err := RiseTransitSet(Moon, london, date)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if times.Rise.IsZero() {
+			missing++
+			continue
+		}
+
+		// the geocentric moon is at the standard altitude when it rises
+		ra, dec := Moon.Equatorial(times.Rise)
+		lst := greenwich_apparent_sidereal_time(julian_datetime(times.Rise)) + london.Longitude
+		elevation, azimuth := equatorial_to_horizontal(london.Latitude, lst-ra, dec)
+		almostEqualFloat(t, elevation, StandardAltitudeMoon, 0.01)
+		if azimuth > 180 {
+			t.Errorf("%v: moon rises in the west at %.1f", times.Rise, azimuth)
+		}
+	}
+
+	// the moon rises about 50 minutes later every day and skips one day a month
+	if missing != 1 {
+		t.Errorf("got %d days without moonrise, want 1", missing)
+	}
+}
+
+func TestRiseTransitSetStars(t *testing.T) {
+	date := time.Date(2024, 6, 21, 0, 0, 0, 0, time.UTC)
+	var tests = []struct {
+		name    string
+		star    Star
+		wantErr error
+	}{
+		{"Polaris", Star{RightAscension: 37.95, Declination: 89.26}, ErrNeverSets},
+		{"Canopus", Star{RightAscension: 95.99, Declination: -52.70}, ErrNeverRises},
+		{"Sirius", Star{RightAscension: 101.29, Declination: -16.72}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			times, err := RiseTransitSet(tt.star, london, date)
+			if err != tt.wantErr {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if times.Transit.IsZero() {
+				t.Errorf("missing transit")
+			}
+			almostEqualFloat(t, times.TransitElevation, 90-math.Abs(london.Latitude-tt.star.Declination), 0.0001)
+			if tt.wantErr == nil && (times.Rise.IsZero() || times.Set.IsZero()) {
+				t.Errorf("missing rise or set: %v", times)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package celestial
+
+import (
+	"time"
+)
+
+// Find the time between lo and hi at which f is smallest using a golden section search.
+// f has to have a single minimum within the interval.
+// Args:
+//
+//	f:         The function to minimise
+//	lo, hi:    The interval to search
+//	precision: The width of the interval at which the search stops
+//
+// Returns:
+//
+//	The time of the minimum, rounded to precision.
+func minimize_time(f func(time.Time) float64, lo, hi time.Time, precision time.Duration) time.Time {
+	const phi = 0.6180339887498949
+
+	span := hi.Sub(lo)
+	a := hi.Add(-time.Duration(float64(span) * phi))
+	b := lo.Add(time.Duration(float64(span) * phi))
+	fa, fb := f(a), f(b)
+	for hi.Sub(lo) > precision {
+		if fa < fb {
+			hi, b, fb = b, a, fa
+			a = hi.Add(-time.Duration(float64(hi.Sub(lo)) * phi))
+			fa = f(a)
+		} else {
+			lo, a, fa = a, b, fb
+			b = lo.Add(time.Duration(float64(hi.Sub(lo)) * phi))
+			fb = f(b)
+		}
+	}
+	return lo.Add(hi.Sub(lo) / 2).Round(precision)
+}
+
+// Find the time between lo and hi at which f is smallest. The moon moves the earth around
+// their barycentre every month, which gives the distance to the sun several local minima
+// near perihelion, so the time is first narrowed down to the smallest of samples every 6
+// hours and then refined with a golden section search.
+func minimize_sampled(f func(time.Time) float64, lo, hi time.Time, precision time.Duration) time.Time {
+	const step = 6 * time.Hour
+	best, fbest := lo, f(lo)
+	for t := lo.Add(step); !t.After(hi); t = t.Add(step) {
+		if ft := f(t); ft < fbest {
+			best, fbest = t, ft
+		}
+	}
+	return minimize_time(f, best.Add(-step), best.Add(step), precision)
+}
@@ -0,0 +1,32 @@
+package celestial
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestMinimizeTime(t *testing.T) {
+	lo := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	hi := lo.Add(24 * time.Hour)
+	want := lo.Add(7*time.Hour + 13*time.Minute + 20*time.Second)
+
+	parabola := func(t time.Time) float64 {
+		h := t.Sub(want).Hours()
+		return h * h
+	}
+	almostEqualTime(t, minimize_time(parabola, lo, hi, time.Second), want, time.Second)
+}
+
+func TestMinimizeSampled(t *testing.T) {
+	lo := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	hi := lo.Add(40 * 24 * time.Hour)
+	want := lo.Add(17*24*time.Hour + 3*time.Hour)
+
+	// A broad minimum with a daily ripple has a local minimum every day.
+	rippled := func(t time.Time) float64 {
+		h := t.Sub(want).Hours()
+		return h*h/1e4 - math.Cos(2*math.Pi*h/24)
+	}
+	almostEqualTime(t, minimize_sampled(rippled, lo, hi, time.Second), want, time.Second)
+}
@@ -7,8 +7,11 @@ import (
 	"time"
 )
 
-// Using 32 arc minutes as sun's apparent diameter
-const sunApperentRadius = 32.0 / (60.0 * 2.0)
+// AstronomicalUnit is the length of the astronomical unit in kilometres
+const AstronomicalUnit = 149597870.7
+
+// The sun's angular radius in arc seconds at a distance of one astronomical unit
+const sunRadiusAtOneAU = 959.63
 
 func degrees(rad float64) float64 {
 	return rad * (180 / math.Pi)
@@ -81,46 +84,51 @@ func sun_true_long(juliancentury float64) float64 {
 }
 
 // Calculate the sun's true anomaly//
-// func sun_true_anomoly(juliancentury float64) float64 {
-// 	m := geom_mean_anomaly_sun(juliancentury)
-// 	c := sun_eq_of_center(juliancentury)
-// 	return m + c
-// }
-
-// func sun_rad_vector(juliancentury float64) float64 {
-// 	v := sun_true_anomoly(juliancentury)
-// 	e := eccentric_location_earth_orbit(juliancentury)
-// 	return (1.000001018 * (1 - e*e)) / (1 + e*math.Cos(radians(v)))
-// }
+func sun_true_anomoly(juliancentury float64) float64 {
+	m := geom_mean_anomaly_sun(juliancentury)
+	c := sun_eq_of_center(juliancentury)
+	return m + c
+}
+
+// Calculate the distance between the centres of the sun and the earth in astronomical units//
+func sun_rad_vector(juliancentury float64) float64 {
+	v := sun_true_anomoly(juliancentury)
+	e := eccentric_location_earth_orbit(juliancentury)
+	return (1.000001018 * (1 - e*e)) / (1 + e*math.Cos(radians(v)))
+}
+
+// Calculate the sun's apparent angular radius in degrees//
+func sun_apparent_radius(juliancentury float64) float64 {
+	return sunRadiusAtOneAU / 3600.0 / sun_rad_vector(juliancentury)
+}
 
 func sun_apparent_long(juliancentury float64) float64 {
 	true_long := sun_true_long(juliancentury)
-
-	omega := 125.04 - 1934.136*juliancentury
-	return true_long - 0.00569 - 0.00478*math.Sin(radians(omega))
+	return true_long - aberrationConstant/sun_rad_vector(juliancentury) + nutation_in_longitude(juliancentury)
 }
 
+// Calculate the mean obliquity of the ecliptic with the IAU 2006 precession
+//
+// See Capitaine et al., Astronomy & Astrophysics 412, 567 (2003)
 func mean_obliquity_of_ecliptic(juliancentury float64) float64 {
-	seconds := 21.448 - juliancentury*(46.815+juliancentury*(0.00059-juliancentury*(0.001813)))
-	return 23.0 + (26.0+(seconds/60.0))/60.0
+	t := juliancentury
+	seconds := 84381.406 + t*(-46.836769+t*(-0.0001831+t*(0.00200340+t*(-0.000000576-t*0.0000000434))))
+	return seconds / 3600.0
 }
 
 func obliquity_correction(juliancentury float64) float64 {
-	e0 := mean_obliquity_of_ecliptic(juliancentury)
-
-	omega := 125.04 - 1934.136*juliancentury
-	return e0 + 0.00256*math.Cos(radians(omega))
+	return mean_obliquity_of_ecliptic(juliancentury) + nutation_in_obliquity(juliancentury)
 }
 
 // Calculate the sun's right ascension
-// func sun_rt_ascension(juliancentury float64) float64 {
-// 	oc := obliquity_correction(juliancentury)
-// 	al := sun_apparent_long(juliancentury)
+func sun_rt_ascension(juliancentury float64) float64 {
+	oc := obliquity_correction(juliancentury)
+	al := sun_apparent_long(juliancentury)
 
-// 	tananum := math.Cos(radians(oc)) * math.Sin(radians(al))
-// 	tanadenom := math.Cos(radians(al))
-// 	return degrees(math.Atan2(tananum, tanadenom))
-// }
+	tananum := math.Cos(radians(oc)) * math.Sin(radians(al))
+	tanadenom := math.Cos(radians(al))
+	return degrees(math.Atan2(tananum, tanadenom))
+}
 
 // Calculate the sun's declination
 func sun_declination(juliancentury float64) float64 {
@@ -155,36 +163,6 @@ func eq_of_time(juliancentury float64) float64 {
 	return degrees(Etime) * 4.0
 }
 
-// Calculate the hour angle of the sun
-//
-// See https://en.wikipedia.org/wiki/Hour_angle#Solar_hour_angle
-// Args:
-//
-//	latitude: The latitude of the obersver
-//	declination: The declination of the sun
-//	zenith: The zenith angle of the sun
-//	direction: The direction of traversal of the sun
-//
-// Raises:
-//
-//	ValueError
-func hour_angle(latitude float64, declination float64, zenith float64, direction SunDirection) (float64, error) {
-	latitude_rad := radians(latitude)
-	declination_rad := radians(declination)
-	zenith_rad := radians(zenith)
-
-	h := (math.Cos(zenith_rad) - math.Sin(latitude_rad)*math.Sin(declination_rad)) / (math.Cos(latitude_rad) * math.Cos(declination_rad))
-
-	hourAngle := math.Acos(h)
-	if math.IsNaN(hourAngle) {
-		return 0, errors.New("not able to determine hour angle")
-	}
-	if direction == SunDirectionSetting {
-		hourAngle = -hourAngle
-	}
-	return hourAngle, nil
-}
-
 // Calculate the extra degrees of depression that you can see round the earth
 // due to the increase in elevation.
 // Args:
@@ -247,6 +225,9 @@ func refraction_at_zenith(zenith float64) float64 {
 }
 
 // Calculate the time in the UTC timezone when the sun transits the specified zenith
+//
+// The time is refined with the same solver as RiseTransitSet, starting from the solar noon
+// at the observer's longitude of the calendar day of the date.
 // Args:
 //
 //	observer: An observer viewing the sun at a specific, latitude, longitude and elevation
@@ -254,61 +235,37 @@ func refraction_at_zenith(zenith float64) float64 {
 //	zenith: The zenith angle for which to calculate the transit time
 //	direction: The direction that the sun is traversing
 //
-// Raises:
-//
-//	ValueError if the zenith is not transited by the sun
-//
 // Returns:
 //
-//	the time when the sun transits the specified zenith
+//	the time when the sun transits the specified zenith, or an error if the sun does not
+//	reach it on the day
 func time_of_transit(observer Observer, date time.Time, zenith float64, direction SunDirection) (time.Time, error) {
-	latitude := observer.Latitude
-	if observer.Latitude > 89.8 {
-		latitude = 89.8
-	} else if observer.Latitude < -89.8 {
-		latitude = -89.8
-	}
-
-	// TODO:
-	// if instance(observer.elevation, float) && observer.elevation > 0.0 {
-	// 	adjustment_for_elevation = adjust_to_horizon(observer.elevation)
-	adjustment_for_elevation := 0.0
-	if observer.Elevation > 0.0 {
-		adjustment_for_elevation = adjust_to_horizon(observer.Elevation)
-	}
-	// } else if instance(observer.elevation, tuple) {
-	// 	adjustment_for_elevation = adjust_to_obscuring_feature(observer.elevation)
-	// }
-
+	latitude := clamp(observer.Latitude, -89.8, 89.8)
+	adjustment_for_elevation := adjust_to_horizon(observer.Elevation)
 	adjustment_for_refraction := refraction_at_zenith(zenith + adjustment_for_elevation)
+	altitude := 90 - (zenith + adjustment_for_elevation + adjustment_for_refraction)
 
-	jd := julianday(date)
-	jc := jday_to_jcentury(jd)
-	solarDec := sun_declination(jc)
-
-	hourangle, err := hour_angle(latitude, solarDec, zenith+adjustment_for_elevation-adjustment_for_refraction, direction)
-	if err != nil {
-		return time.Time{}, err
+	sign := 1.0
+	if direction == SunDirectionRising {
+		sign = -1
 	}
-
-	delta := -observer.Longitude - degrees(hourangle)
-	timeDiff := 4.0 * delta
-	timeUTC := 720.0 + timeDiff - eq_of_time(jc)
-
-	jc = jday_to_jcentury(jcentury_to_jday(jc) + timeUTC/1440.0)
-	solarDec = sun_declination(jc)
-	hourangle, err = hour_angle(latitude, solarDec, zenith+adjustment_for_elevation+adjustment_for_refraction, direction)
-	if err != nil {
-		return time.Time{}, err
+	target := altitude_hour_angle(latitude, altitude, sign)
+
+	// start from the hour angle at the solar noon of the day of the date, taken on UTC
+	noon := time.Date(date.Year(), date.Month(), date.Day(), 12, 0, 0, 0, time.UTC).Add(time.Duration(-observer.Longitude / 15 * float64(time.Hour)))
+	// near the end of the polar day or night the sun only grazes the altitude close to
+	// midnight, with the declination of then and not of noon
+	for _, offset := range []float64{0, sign * 12} {
+		_, dec := Sun.Equatorial(noon.Add(time.Duration(offset * float64(time.Hour))))
+		h, ok := target(dec)
+		if !ok {
+			continue
+		}
+		if t, ok := refine_hour_angle(Sun, observer, noon.Add(time.Duration(h/siderealRate*24*float64(time.Hour))), target); ok {
+			return t.In(date.Location()), nil
+		}
 	}
-
-	delta = -observer.Longitude - degrees(hourangle)
-	timeDiff = 4.0 * delta
-	timeUTC = 720 + timeDiff - eq_of_time(jc)
-
-	td := minutes_to_timedelta(timeUTC)
-	dt := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC).Add(td).In(date.Location())
-	return dt, nil
+	return time.Time{}, errors.New("not able to determine hour angle")
 }
 
 // Calculates the time when the sun is at the specified elevation on the specified date.
@@ -449,15 +406,15 @@ func ZenithAndAzimuth(observer Observer, dateandtime time.Time, with_refraction
 
 	utc_datetime := dateandtime.UTC()
 
-	timenow := (utc_datetime.Hour() + (utc_datetime.Minute() / 60.0) + (utc_datetime.Second() / 3600.0))
+	timenow := float64(utc_datetime.Hour()) + float64(utc_datetime.Minute())/60.0 + float64(utc_datetime.Second())/3600.0
 
 	JD := julianday(dateandtime)
-	t := jday_to_jcentury(JD + float64(timenow)/24.0)
+	t := jday_to_jcentury(JD + timenow/24.0)
 	solarDec := sun_declination(t)
 	eqtime := eq_of_time(t)
 
 	solarTimeFix := eqtime - (4.0 * -longitude)
-	trueSolarTime := float64(utc_datetime.Hour()*60+utc_datetime.Minute()+utc_datetime.Second()/60) + solarTimeFix
+	trueSolarTime := timenow*60.0 + solarTimeFix
 	//    in minutes as a float, fractional part is seconds
 
 	for trueSolarTime > 1440 {
@@ -595,7 +552,8 @@ var (
 //
 //	Date and time at which sunrise occurs.
 func Sunrise(observer Observer, date time.Time) (time.Time, error) {
-	t, err := time_of_transit(observer, date, 90.0+sunApperentRadius, SunDirectionRising)
+	jc := jday_to_jcentury(julianday(date))
+	t, err := time_of_transit(observer, date, 90.0+sun_apparent_radius(jc), SunDirectionRising)
 
 	if err != nil {
 		z := Zenith(observer, Noon(observer, date), true)
@@ -630,7 +588,8 @@ func Sunrise(observer Observer, date time.Time) (time.Time, error) {
 //			date := today(tzinfo)
 //		}
 func Sunset(observer Observer, date time.Time) (time.Time, error) {
-	t, err := time_of_transit(observer, date, 90.0+sunApperentRadius, SunDirectionSetting)
+	jc := jday_to_jcentury(julianday(date))
+	t, err := time_of_transit(observer, date, 90.0+sun_apparent_radius(jc), SunDirectionSetting)
 	if err != nil {
 		z := Zenith(observer, Noon(observer, date), true)
 		if z > 90.0 {
@@ -843,3 +802,128 @@ func BlueHour(observer Observer, date time.Time, direction SunDirection) (time.T
 	return end, start, nil
 
 }
+
+// SunDistance calculates the distance between the centres of the earth and the sun.
+// Args:
+//
+//	dateandtime: The date and time for which to calculate the distance.
+//
+// Returns:
+//
+//	The distance in astronomical units and in kilometres.
+func SunDistance(dateandtime time.Time) (float64, float64) {
+	r := earth_sun_distance(dateandtime)
+	return r, r * AstronomicalUnit
+}
+
+// SunApparentRadius calculates the angular radius of the sun's disk as seen from the earth.
+// Args:
+//
+//	dateandtime: The date and time for which to calculate the radius.
+//
+// Returns:
+//
+//	The angular radius in degrees.
+func SunApparentRadius(dateandtime time.Time) float64 {
+	return sunRadiusAtOneAU / 3600.0 / earth_sun_distance(dateandtime)
+}
+
+// Calculate the distance between the centres of the earth and the sun in AU from VSOP87
+func earth_sun_distance(dateandtime time.Time) float64 {
+	_, _, r := earth_heliocentric(jday_to_jcentury(julian_ephemeris_day(dateandtime)))
+	return r
+}
+
+// Perihelion calculates when the earth is closest to the sun.
+//
+// The distance is that of the centre of the earth from VSOP87, which includes the monthly
+// wobble caused by the moon. The distance changes so slowly near perihelion that the
+// truncated series still move the result by up to an hour from published times.
+// Args:
+//
+//	year: The year to calculate for. Perihelion always falls in early January.
+//
+// Returns:
+//
+//	The time of perihelion in UTC.
+func Perihelion(year int) time.Time {
+	lo := time.Date(year-1, 12, 15, 0, 0, 0, 0, time.UTC)
+	hi := time.Date(year, 1, 25, 0, 0, 0, 0, time.UTC)
+	return minimize_sampled(earth_sun_distance, lo, hi, time.Minute)
+}
+
+// Aphelion calculates when the earth is farthest from the sun.
+//
+// See Perihelion for the accuracy of the result.
+// Args:
+//
+//	year: The year to calculate for. Aphelion always falls in early July.
+//
+// Returns:
+//
+//	The time of aphelion in UTC.
+func Aphelion(year int) time.Time {
+	lo := time.Date(year, 6, 15, 0, 0, 0, 0, time.UTC)
+	hi := time.Date(year, 7, 25, 0, 0, 0, 0, time.UTC)
+	return minimize_sampled(func(t time.Time) float64 { return -earth_sun_distance(t) }, lo, hi, time.Minute)
+}
+
+// SolarPosition holds the geocentric coordinates of the sun.
+type SolarPosition struct {
+	// ApparentLongitude is the sun's apparent ecliptic longitude in degrees
+	ApparentLongitude float64
+	// RightAscension is the sun's apparent right ascension in degrees
+	RightAscension float64
+	// Declination is the sun's apparent declination in degrees
+	Declination float64
+	// EquationOfTime is apparent minus mean solar time in minutes
+	EquationOfTime float64
+}
+
+// SolarCoordinates calculates the position of the sun as seen from the centre of the earth.
+// Args:
+//
+//	dateandtime: The date and time for which to calculate the coordinates.
+//
+// Returns:
+//
+//	The sun's apparent longitude, right ascension and declination and the equation of time.
+func SolarCoordinates(dateandtime time.Time) SolarPosition {
+	jc := jday_to_jcentury(julian_datetime(dateandtime))
+	return SolarPosition{
+		ApparentLongitude: properAngle(sun_apparent_long(jc)),
+		RightAscension:    properAngle(sun_rt_ascension(jc)),
+		Declination:       sun_declination(jc),
+		EquationOfTime:    eq_of_time(jc),
+	}
+}
+
+// LocalMeanTime converts a time to the mean solar time at the observer's longitude.
+// Args:
+//
+//	observer:    Observer to calculate for
+//	dateandtime: The date and time to convert.
+//
+// Returns:
+//
+//	The same instant in a fixed "LMT" timezone that is offset from UTC by the observer's longitude.
+func LocalMeanTime(observer Observer, dateandtime time.Time) time.Time {
+	offset := minutes_to_timedelta(4.0 * observer.Longitude)
+	return dateandtime.In(time.FixedZone("LMT", int(offset.Seconds())))
+}
+
+// ApparentSolarTime converts a time to the apparent solar time at the observer's longitude,
+// the time a sundial shows. The sun crosses the meridian at 12:00 apparent solar time.
+// Args:
+//
+//	observer:    Observer to calculate for
+//	dateandtime: The date and time to convert.
+//
+// Returns:
+//
+//	The same instant in a fixed "LAT" timezone that is offset from local mean time by the equation of time.
+func ApparentSolarTime(observer Observer, dateandtime time.Time) time.Time {
+	jc := jday_to_jcentury(julian_datetime(dateandtime))
+	offset := minutes_to_timedelta(4.0*observer.Longitude + eq_of_time(jc))
+	return dateandtime.In(time.FixedZone("LAT", int(offset.Seconds())))
+}
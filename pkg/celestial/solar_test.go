@@ -550,3 +550,125 @@ func TestAdjustToObscuringFeature(t *testing.T) {
 		})
 	}
 }
+
+func TestSunDistance(t *testing.T) {
+	type args struct {
+		date time.Time
+	}
+	tests := []struct {
+		name   string
+		args   args
+		wantAU float64
+	}{
+		// Meeus, Astronomical Algorithms, example 25.b
+		{args: args{date: time.Date(1992, 10, 13, 0, 0, 0, 0, time.UTC)}, wantAU: 0.99760775},
+		{args: args{date: time.Date(2024, 1, 3, 0, 39, 0, 0, time.UTC)}, wantAU: 0.983307},
+		{args: args{date: time.Date(2024, 7, 5, 5, 6, 0, 0, time.UTC)}, wantAU: 1.016725},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			au, km := SunDistance(tt.args.date)
+			almostEqualFloat(t, au, tt.wantAU, 0.000002)
+			almostEqualFloat(t, km, tt.wantAU*AstronomicalUnit, 0.000002*AstronomicalUnit)
+		})
+	}
+}
+
+func TestSunApparentRadius(t *testing.T) {
+	type args struct {
+		date time.Time
+	}
+	tests := []struct {
+		name string
+		args args
+		want float64
+	}{
+		{args: args{date: time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)}, want: 16.27 / 60},
+		{args: args{date: time.Date(2024, 7, 5, 0, 0, 0, 0, time.UTC)}, want: 15.73 / 60},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SunApparentRadius(tt.args.date)
+			almostEqualFloat(t, got, tt.want, 0.01/60)
+		})
+	}
+}
+
+func TestPerihelionAndAphelion(t *testing.T) {
+	type args struct {
+		year int
+	}
+	tests := []struct {
+		name           string
+		args           args
+		wantPerihelion time.Time
+		wantAphelion   time.Time
+	}{
+		{args: args{year: 2021}, wantPerihelion: time.Date(2021, 1, 2, 13, 51, 0, 0, time.UTC), wantAphelion: time.Date(2021, 7, 5, 22, 27, 0, 0, time.UTC)},
+		{args: args{year: 2022}, wantPerihelion: time.Date(2022, 1, 4, 6, 52, 0, 0, time.UTC), wantAphelion: time.Date(2022, 7, 4, 7, 11, 0, 0, time.UTC)},
+		{args: args{year: 2024}, wantPerihelion: time.Date(2024, 1, 3, 0, 39, 0, 0, time.UTC), wantAphelion: time.Date(2024, 7, 5, 5, 6, 0, 0, time.UTC)},
+		{args: args{year: 2025}, wantPerihelion: time.Date(2025, 1, 4, 13, 28, 0, 0, time.UTC), wantAphelion: time.Date(2025, 7, 3, 19, 55, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			almostEqualTime(t, Perihelion(tt.args.year), tt.wantPerihelion, time.Hour)
+			almostEqualTime(t, Aphelion(tt.args.year), tt.wantAphelion, time.Hour)
+		})
+	}
+}
+
+func TestSolarCoordinates(t *testing.T) {
+	type args struct {
+		date time.Time
+	}
+	tests := []struct {
+		name string
+		args args
+		want SolarPosition
+	}{
+		// Meeus, Astronomical Algorithms, examples 25.a and 28.a
+		{args: args{date: time.Date(1992, 10, 13, 0, 0, 0, 0, time.UTC)}, want: SolarPosition{ApparentLongitude: 199.90895, RightAscension: 198.38083, Declination: -7.78507, EquationOfTime: 13.711}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SolarCoordinates(tt.args.date)
+			almostEqualFloat(t, got.ApparentLongitude, tt.want.ApparentLongitude, 0.001)
+			almostEqualFloat(t, got.RightAscension, tt.want.RightAscension, 0.001)
+			almostEqualFloat(t, got.Declination, tt.want.Declination, 0.001)
+			almostEqualFloat(t, got.EquationOfTime, tt.want.EquationOfTime, 0.1)
+		})
+	}
+}
+
+func TestLocalMeanTime(t *testing.T) {
+	date := time.Date(2015, 12, 1, 12, 0, 0, 0, time.UTC)
+
+	got := LocalMeanTime(newDelhi, date)
+	if !got.Equal(date) {
+		t.Fatalf("LocalMeanTime() = %v, is not the same instant as %v", got, date)
+	}
+	if h, m := got.Hour(), got.Minute(); h != 17 || m != 8 {
+		t.Fatalf("LocalMeanTime() = %v, want 17:08", got)
+	}
+}
+
+func TestApparentSolarTime(t *testing.T) {
+	type args struct {
+		observer Observer
+		date     time.Time
+	}
+	tests := []struct {
+		name string
+		args args
+	}{
+		{args: args{observer: london, date: time.Date(2015, 12, 1, 0, 0, 0, 0, time.UTC)}},
+		{args: args{observer: newDelhi, date: time.Date(2016, 2, 11, 0, 0, 0, 0, time.UTC)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			noon := ApparentSolarTime(tt.args.observer, Noon(tt.args.observer, tt.args.date))
+			want := time.Date(noon.Year(), noon.Month(), noon.Day(), 12, 0, 0, 0, noon.Location())
+			almostEqualTime(t, noon, want, 30*time.Second)
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package celestial
+
+import (
+	"fmt"
+	"time"
+)
+
+// SolarTerm is one of the 24 solar terms (jieqi) of the Chinese calendar, at which the
+// apparent longitude of the sun reaches a multiple of 15 degrees. The terms are numbered
+// from the March equinox at longitude 0.
+type SolarTerm int
+
+const (
+	Chunfen SolarTerm = iota
+	Qingming
+	Guyu
+	Lixia
+	Xiaoman
+	Mangzhong
+	Xiazhi
+	Xiaoshu
+	Dashu
+	Liqiu
+	Chushu
+	Bailu
+	Qiufen
+	Hanlu
+	Shuangjiang
+	Lidong
+	Xiaoxue
+	Daxue
+	Dongzhi
+	Xiaohan
+	Dahan
+	Lichun
+	Yushui
+	Jingzhe
+)
+
+var solarTermNames = [...]string{
+	"Chunfen", "Qingming", "Guyu", "Lixia", "Xiaoman", "Mangzhong",
+	"Xiazhi", "Xiaoshu", "Dashu", "Liqiu", "Chushu", "Bailu",
+	"Qiufen", "Hanlu", "Shuangjiang", "Lidong", "Xiaoxue", "Daxue",
+	"Dongzhi", "Xiaohan", "Dahan", "Lichun", "Yushui", "Jingzhe",
+}
+
+func (t SolarTerm) String() string {
+	if t < Chunfen || t > Jingzhe {
+		return fmt.Sprintf("%%!SolarTerm(%d)", int(t))
+	}
+	return solarTermNames[t]
+}
+
+// Longitude is the apparent longitude of the sun at the term in degrees.
+func (t SolarTerm) Longitude() float64 {
+	return float64(t) * 15
+}
+
+// Major tells whether the term is a major term (zhongqi), at a multiple of 30 degrees, which
+// decide the numbers of the months of the Chinese calendar. Dongzhi, the December solstice,
+// always falls in the 11th month.
+func (t SolarTerm) Major() bool {
+	return t%2 == 0
+}
+
+// SolarTermTime is the instant of a solar term.
+type SolarTermTime struct {
+	Term SolarTerm
+	Time time.Time
+}
+
+// SolarTerms calculates the instants of the solar terms in a time range.
+//
+// The terms are found with the VSOP87 longitude of SunApparentLongitude, the series of
+// sun_apparent_long can be off by a quarter of an hour, which moves a term near midnight
+// to another day.
+// Returns:
+//
+//	The solar terms in the range in order, in UTC to the second.
+func SolarTerms(from, to time.Time) []SolarTermTime {
+	var terms []SolarTermTime
+	// the next term is less than 15 degrees ahead of the sun, about 15 days
+	term := SolarTerm(int(SunApparentLongitude(from)/15+1) % 24)
+	for t := from; ; term = (term + 1) % 24 {
+		t = sun_longitude_time(SunApparentLongitude, term.Longitude(), t).UTC()
+		if !t.Before(to) {
+			return terms
+		}
+		if !t.Before(from) {
+			terms = append(terms, SolarTermTime{term, t})
+		}
+	}
+}
+
+// NextSolarTerm calculates the first instant of a solar term at or after a time.
+// Returns:
+//
+//	The instant in UTC to the second.
+func NextSolarTerm(term SolarTerm, after time.Time) time.Time {
+	days := angle_difference(term.Longitude(), SunApparentLongitude(after)) / (360 / 365.2422)
+	t := sun_longitude_time(SunApparentLongitude, term.Longitude(), after.Add(time.Duration(days*24*float64(time.Hour))))
+	if t.Before(after) {
+		t = sun_longitude_time(SunApparentLongitude, term.Longitude(), t.AddDate(1, 0, 0))
+	}
+	return t.UTC()
+}
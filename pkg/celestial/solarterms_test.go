@@ -0,0 +1,71 @@
+package celestial
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSolarTerms(t *testing.T) {
+	// terms of 2024 in China Standard Time from the Purple Mountain Observatory
+	cst := time.FixedZone("CST", 8*3600)
+	var tests = []SolarTermTime{
+		{Xiaohan, time.Date(2024, 1, 6, 4, 49, 0, 0, cst)},
+		{Lichun, time.Date(2024, 2, 4, 16, 27, 0, 0, cst)},
+		{Chunfen, time.Date(2024, 3, 20, 11, 6, 0, 0, cst)},
+		{Qingming, time.Date(2024, 4, 4, 15, 2, 0, 0, cst)},
+		{Xiazhi, time.Date(2024, 6, 21, 4, 51, 0, 0, cst)},
+		{Qiufen, time.Date(2024, 9, 22, 20, 44, 0, 0, cst)},
+		{Dongzhi, time.Date(2024, 12, 21, 17, 21, 0, 0, cst)},
+	}
+
+	terms := SolarTerms(time.Date(2024, 1, 1, 0, 0, 0, 0, cst), time.Date(2025, 1, 1, 0, 0, 0, 0, cst))
+	if len(terms) != 24 {
+		t.Fatalf("got %d terms", len(terms))
+	}
+	for i := 1; i < len(terms); i++ {
+		if terms[i].Term != (terms[i-1].Term+1)%24 || !terms[i].Time.After(terms[i-1].Time) {
+			t.Fatalf("%v follows %v", terms[i], terms[i-1])
+		}
+	}
+	for _, test := range tests {
+		t.Run(test.Term.String(), func(t *testing.T) {
+			for _, term := range terms {
+				if term.Term == test.Term {
+					almostEqualTime(t, term.Time, test.Time, time.Minute)
+					almostEqualFloat(t, SunApparentLongitude(term.Time), test.Term.Longitude(), 0.001)
+					return
+				}
+			}
+			t.Fatalf("%v not found", test.Term)
+		})
+	}
+}
+
+func TestNextSolarTerm(t *testing.T) {
+	var tests = []struct {
+		term  SolarTerm
+		after time.Time
+		want  time.Time
+	}{
+		{Lichun, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 4, 8, 27, 0, 0, time.UTC)},
+		// a term right at the start is found
+		{Lichun, time.Date(2024, 2, 4, 8, 27, 7, 0, time.UTC), time.Date(2024, 2, 4, 8, 27, 7, 0, time.UTC)},
+		{Lichun, time.Date(2024, 2, 5, 0, 0, 0, 0, time.UTC), time.Date(2025, 2, 3, 14, 10, 0, 0, time.UTC)},
+		{Dongzhi, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 12, 21, 9, 21, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.term.String(), func(t *testing.T) {
+			almostEqualTime(t, NextSolarTerm(test.term, test.after), test.want, time.Minute)
+		})
+	}
+}
+
+func TestSolarTermMajor(t *testing.T) {
+	if !Dongzhi.Major() || !Chunfen.Major() || Lichun.Major() {
+		t.Error("wrong major terms")
+	}
+	if got := SolarTerm(24).String(); got != "%!SolarTerm(24)" {
+		t.Errorf("got %q", got)
+	}
+}
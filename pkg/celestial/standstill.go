@@ -0,0 +1,216 @@
+package celestial
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// LunarNode calculates the longitude of the ascending node of the moon's orbit, where the
+// moon crosses the ecliptic northwards. The node regresses once around the ecliptic in
+// 18.6 years.
+//
+// See Meeus, Astronomical Algorithms, chapter 47
+// Returns:
+//
+//	The longitude of the mean node and of the true node, which oscillates around it by up
+//	to 1.7 degrees, in degrees referred to the mean equinox of date.
+func LunarNode(dateandtime time.Time) (float64, float64) {
+	jc := jday_to_jcentury(julian_ephemeris_day(dateandtime))
+	mean := moon_mean_node(jc)
+	_, d, m, mp, f := moon_arguments(jc)
+	d, m, mp, f = radians(d), radians(m), radians(mp), radians(f)
+	trueNode := mean - 1.4979*math.Sin(2*(d-f)) - 0.1500*math.Sin(m) - 0.1226*math.Sin(2*d) +
+		0.1176*math.Sin(2*f) - 0.0801*math.Sin(2*(mp-f))
+	return mean, properAngle(trueNode)
+}
+
+// Standstill tells whether the monthly swing of the moon's declination is near its
+// largest or its smallest in the 18.6 year cycle of the lunar node.
+type Standstill int
+
+const (
+	NoStandstill Standstill = iota
+	// MajorStandstill is the time around the ascending node passing the vernal equinox, when
+	// the moon swings between about ±28.6 degrees of declination, the obliquity of the
+	// ecliptic plus the inclination of the lunar orbit
+	MajorStandstill
+	// MinorStandstill is the time around the ascending node passing the autumnal equinox,
+	// when the moon swings between about ±18.1 degrees, their difference
+	MinorStandstill
+)
+
+func (s Standstill) String() string {
+	switch s {
+	case MajorStandstill:
+		return "Major Standstill"
+	case MinorStandstill:
+		return "Minor Standstill"
+	}
+	return "No Standstill"
+}
+
+// The motion of the mean lunar node in degrees per year, retrograde
+const nodeRate = 1934.1362891 / 100
+
+// LunarStandstillPeriod tells whether a time falls into the period of a major or minor lunar
+// standstill, within a year of the mean node passing an equinox. The declination extremes
+// change by less than half a degree in that period.
+func LunarStandstillPeriod(dateandtime time.Time) Standstill {
+	mean, _ := LunarNode(dateandtime)
+	switch {
+	case math.Abs(angle_difference(mean, 0)) <= nodeRate:
+		return MajorStandstill
+	case math.Abs(angle_difference(mean, 180)) <= nodeRate:
+		return MinorStandstill
+	}
+	return NoStandstill
+}
+
+// LunarStandstillTime is the middle of a lunar standstill period.
+type LunarStandstillTime struct {
+	Time       time.Time
+	Standstill Standstill
+}
+
+// LunarStandstills finds the times at which the mean lunar node passes the equinoxes, the
+// middle of the major and minor standstills.
+// Args:
+//
+//	from, to: The time range to search
+//
+// Returns:
+//
+//	The standstills in the range in UTC.
+func LunarStandstills(from, to time.Time) []LunarStandstillTime {
+	var standstills []LunarStandstillTime
+	mean, _ := LunarNode(from)
+	// the node regresses, so the next equinox is the one below its longitude
+	target := math.Floor(mean/180) * 180
+	t := from.Add(time.Duration((mean - target) / nodeRate * 365.25 * 24 * float64(time.Hour)))
+	for {
+		for i := 0; i < 5; i++ {
+			mean, _ = LunarNode(t)
+			t = t.Add(time.Duration(angle_difference(mean, target) / nodeRate * 365.25 * 24 * float64(time.Hour)))
+		}
+		if t.After(to) {
+			return standstills
+		}
+		standstill := MajorStandstill
+		if properAngle(target) == 180 {
+			standstill = MinorStandstill
+		}
+		if !t.Before(from) {
+			standstills = append(standstills, LunarStandstillTime{Time: t.Round(time.Hour).UTC(), Standstill: standstill})
+		}
+		target -= 180
+		t = t.Add(time.Duration(180 / nodeRate * 365.25 * 24 * float64(time.Hour)))
+	}
+}
+
+// LunarDeclinationExtreme is the northernmost or southernmost declination of the moon in
+// a month.
+type LunarDeclinationExtreme struct {
+	Time time.Time
+	// Declination is the geocentric apparent declination in degrees
+	Declination float64
+	North       bool
+	Standstill  Standstill
+}
+
+// LunarDeclinationExtremes finds the times at which the moon reaches its greatest northern
+// and southern declinations, twice in every tropical month of 27.3 days.
+// Args:
+//
+//	from, to: The time range to search
+//
+// Returns:
+//
+//	The extremes in the range in UTC, in order.
+func LunarDeclinationExtremes(from, to time.Time) []LunarDeclinationExtreme {
+	const step = 12 * time.Hour
+	declination := func(t time.Time) float64 {
+		_, dec := Moon.Equatorial(t)
+		return dec
+	}
+
+	var extremes []LunarDeclinationExtreme
+	add := func(times []time.Time, north bool) {
+		for _, t := range times {
+			extremes = append(extremes, LunarDeclinationExtreme{
+				Time:        t.UTC(),
+				Declination: declination(t),
+				North:       north,
+				Standstill:  LunarStandstillPeriod(t),
+			})
+		}
+	}
+	add(local_minima(func(t time.Time) float64 { return -declination(t) }, from, to, step), true)
+	add(local_minima(declination, from, to, step), false)
+	sort.Slice(extremes, func(i, j int) bool { return extremes[i].Time.Before(extremes[j].Time) })
+	return extremes
+}
+
+// MoonHorizonExtreme is the northernmost or southernmost rising and setting of the moon
+// around one of its declination extremes.
+type MoonHorizonExtreme struct {
+	LunarDeclinationExtreme
+	// Rise and Set are the times with the most extreme azimuth within a day of the
+	// declination extreme, zero if the moon does not rise or set then
+	Rise        time.Time
+	RiseAzimuth float64
+	Set         time.Time
+	SetAzimuth  float64
+}
+
+// MoonHorizonExtremes finds where on the horizon the moon rises and sets at its monthly
+// extremes, the alignments studied in archaeoastronomy.
+//
+// The azimuths are those of the geocentric moon at its standard altitude, as used for
+// the rising and setting times. On a flat horizon the topocentric moon appears about a
+// degree lower, which shifts the azimuths slightly towards the south at northern latitudes.
+// Args:
+//
+//	observer: Observer to calculate for
+//	from, to: The time range to search
+//
+// Returns:
+//
+//	The azimuths in degrees clockwise from North for every declination extreme in the
+//	range, in order.
+func MoonHorizonExtremes(observer Observer, from, to time.Time) []MoonHorizonExtreme {
+	var result []MoonHorizonExtreme
+	for _, extreme := range LunarDeclinationExtremes(from, to) {
+		horizon := MoonHorizonExtreme{LunarDeclinationExtreme: extreme}
+		// the most extreme azimuth is the one closest to the pole the moon is nearest to
+		further := func(azimuth, current float64, found bool) bool {
+			if !found {
+				return true
+			}
+			if extreme.North {
+				return math.Abs(angle_difference(azimuth, 0)) < math.Abs(angle_difference(current, 0))
+			}
+			return math.Abs(angle_difference(azimuth, 180)) < math.Abs(angle_difference(current, 180))
+		}
+
+		day := time.Date(extreme.Time.Year(), extreme.Time.Month(), extreme.Time.Day(), 0, 0, 0, 0, time.UTC)
+		for d := day.AddDate(0, 0, -1); !d.After(day.AddDate(0, 0, 1)); d = d.AddDate(0, 0, 1) {
+			times, err := RiseTransitSet(Moon, observer, d)
+			if err != nil {
+				continue
+			}
+			if !times.Rise.IsZero() && times.Rise.Sub(extreme.Time).Abs() <= 24*time.Hour {
+				if _, azimuth := body_horizontal(Moon, observer, times.Rise); further(azimuth, horizon.RiseAzimuth, !horizon.Rise.IsZero()) {
+					horizon.Rise, horizon.RiseAzimuth = times.Rise, azimuth
+				}
+			}
+			if !times.Set.IsZero() && times.Set.Sub(extreme.Time).Abs() <= 24*time.Hour {
+				if _, azimuth := body_horizontal(Moon, observer, times.Set); further(azimuth, horizon.SetAzimuth, !horizon.Set.IsZero()) {
+					horizon.Set, horizon.SetAzimuth = times.Set, azimuth
+				}
+			}
+		}
+		result = append(result, horizon)
+	}
+	return result
+}
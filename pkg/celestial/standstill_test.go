@@ -0,0 +1,143 @@
+package celestial
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// The osculating node of the moon's orbit from the position and velocity of moon_position
+func osculating_node(juliancentury float64) float64 {
+	position := func(jc float64) vec3 {
+		return spherical_vector(moon_position(jc))
+	}
+	const h = 0.01 / 36525
+	r := position(juliancentury)
+	v := position(juliancentury + h).sub(position(juliancentury - h))
+	// the node lies along the cross product of the z axis and the orbit's normal r × v
+	nx, ny := r[1]*v[2]-r[2]*v[1], r[2]*v[0]-r[0]*v[2]
+	return properAngle(degrees(math.Atan2(nx, -ny)))
+}
+
+func TestLunarNode(t *testing.T) {
+	// See Meeus, Astronomical Algorithms, example 47.a, where Ω = 274.400656 at 0h TD
+	mean, trueNode := LunarNode(time.Date(1992, 4, 12, 0, 0, 0, 0, time.UTC).Add(-time.Minute))
+	almostEqualFloat(t, mean, 274.400656, 0.0001)
+	if d := angle_difference(trueNode, mean); d < -1.8 || d > 1.8 || d == 0 {
+		t.Fatalf("true node %f, mean node %f", trueNode, mean)
+	}
+
+	// every periodic term of the true node is that of the osculating node, so the
+	// difference between them has no part left at the terms' arguments
+	var terms [5]float64
+	samples := 0
+	for dateandtime := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC); dateandtime.Year() < 2019; dateandtime = dateandtime.Add(13 * time.Hour) {
+		jc := jday_to_jcentury(julian_ephemeris_day(dateandtime))
+		_, trueNode := LunarNode(dateandtime)
+		difference := angle_difference(trueNode, osculating_node(jc))
+
+		_, d, m, mp, f := moon_arguments(jc)
+		d, m, mp, f = radians(d), radians(m), radians(mp), radians(f)
+		for i, argument := range []float64{2 * (d - f), m, 2 * d, 2 * f, 2 * (mp - f)} {
+			terms[i] += 2 * difference * math.Sin(argument)
+		}
+		samples++
+	}
+	for i := range terms {
+		almostEqualFloat(t, terms[i]/float64(samples), 0, 0.01)
+	}
+}
+
+func TestLunarStandstills(t *testing.T) {
+	standstills := LunarStandstills(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2040, 1, 1, 0, 0, 0, 0, time.UTC))
+	var want = []LunarStandstillTime{
+		{time.Date(2006, 6, 19, 0, 0, 0, 0, time.UTC), MajorStandstill},
+		{time.Date(2015, 10, 10, 0, 0, 0, 0, time.UTC), MinorStandstill},
+		{time.Date(2025, 1, 29, 0, 0, 0, 0, time.UTC), MajorStandstill},
+		{time.Date(2034, 5, 21, 0, 0, 0, 0, time.UTC), MinorStandstill},
+	}
+	if len(standstills) != len(want) {
+		t.Fatalf("standstills %v", standstills)
+	}
+	for i, standstill := range standstills {
+		if standstill.Standstill != want[i].Standstill {
+			t.Fatalf("%v: got %v", want[i].Time, standstill.Standstill)
+		}
+		almostEqualTime(t, standstill.Time, want[i].Time, 24*time.Hour)
+		mean, _ := LunarNode(standstill.Time)
+		almostEqualFloat(t, math.Min(math.Abs(angle_difference(mean, 0)), math.Abs(angle_difference(mean, 180))), 0, 0.01)
+	}
+
+	var periods = []struct {
+		date time.Time
+		want Standstill
+	}{
+		{time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), MajorStandstill},
+		{time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC), MajorStandstill},
+		{time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), NoStandstill},
+		{time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC), MinorStandstill},
+		{time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), NoStandstill},
+	}
+	for _, period := range periods {
+		if got := LunarStandstillPeriod(period.date); got != period.want {
+			t.Fatalf("%v: got %v, want %v", period.date, got, period.want)
+		}
+	}
+}
+
+func TestLunarDeclinationExtremes(t *testing.T) {
+	var tests = []struct {
+		from, to   time.Time
+		min, max   float64
+		standstill Standstill
+	}{
+		// the moon swings between about ±28.5 degrees at the major standstill and ±18.2
+		// degrees at the minor standstill
+		{time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), 28.3, 28.7, MajorStandstill},
+		{time.Date(2015, 9, 1, 0, 0, 0, 0, time.UTC), time.Date(2015, 12, 1, 0, 0, 0, 0, time.UTC), 18.0, 18.4, MinorStandstill},
+		{time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC), 18.4, 28.3, NoStandstill},
+	}
+
+	for _, test := range tests {
+		t.Run(test.from.String(), func(t *testing.T) {
+			extremes := LunarDeclinationExtremes(test.from, test.to)
+			if len(extremes) < 6 {
+				t.Fatalf("got %d extremes", len(extremes))
+			}
+			for i, extreme := range extremes {
+				if i > 0 && extreme.North == extremes[i-1].North {
+					t.Fatalf("%v follows %v", extreme, extremes[i-1])
+				}
+				if (extreme.Declination > 0) != extreme.North || extreme.Standstill != test.standstill {
+					t.Fatalf("extreme %v", extreme)
+				}
+				if d := math.Abs(extreme.Declination); d < test.min || d > test.max {
+					t.Fatalf("%v: declination %f", extreme.Time, extreme.Declination)
+				}
+			}
+		})
+	}
+}
+
+func TestMoonHorizonExtremes(t *testing.T) {
+	// the northernmost moonrise at Stonehenge near the major standstill
+	stonehenge := Observer{Latitude: 51.1789, Longitude: -1.8262}
+	extremes := MoonHorizonExtremes(stonehenge, time.Date(2024, 12, 10, 0, 0, 0, 0, time.UTC), time.Date(2024, 12, 20, 0, 0, 0, 0, time.UTC))
+	if len(extremes) != 1 || !extremes[0].North {
+		t.Fatalf("extremes %v", extremes)
+	}
+	extreme := extremes[0]
+	almostEqualFloat(t, extreme.RiseAzimuth, 40.9, 0.3)
+	almostEqualFloat(t, extreme.SetAzimuth, 360-40.9, 0.5)
+
+	// the moon rises further south on the days around the extreme
+	for _, day := range []int{-1, 1} {
+		times, err := RiseTransitSet(Moon, stonehenge, extreme.Rise.AddDate(0, 0, day))
+		if err != nil || times.Rise.IsZero() {
+			continue
+		}
+		if _, azimuth := body_horizontal(Moon, stonehenge, times.Rise); azimuth < extreme.RiseAzimuth {
+			t.Fatalf("%v: rises at %f, north of %f", times.Rise, azimuth, extreme.RiseAzimuth)
+		}
+	}
+}
@@ -0,0 +1,355 @@
+package celestial
+
+import (
+	"bufio"
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+//go:embed data/brightstars.csv
+var brightStarsCSV string
+
+var (
+	brightStars     []CatalogStar
+	brightStarsOnce sync.Once
+)
+
+// CatalogStar is a star of a catalogue with its position at epoch and equinox J2000.
+// It implements Body so that it can be used with RiseTransitSet.
+type CatalogStar struct {
+	// HR is the number of the star in the Yale Bright Star Catalogue
+	HR   int
+	Name string
+	// RightAscension and Declination are the J2000 position in degrees
+	RightAscension float64
+	Declination    float64
+	Magnitude      float64
+	// ProperMotionRA is the proper motion in right ascension times the cosine of the
+	// declination, ProperMotionDec that in declination, both in milliarcseconds per year
+	ProperMotionRA  float64
+	ProperMotionDec float64
+}
+
+// Parse a sexagesimal value such as "06:45:08.92" or "-16:42:58.0"
+func parse_sexagesimal(value string) (float64, error) {
+	value = strings.TrimSpace(value)
+	sign := 1.0
+	if strings.HasPrefix(value, "-") {
+		sign = -1
+	}
+	value = strings.TrimLeft(value, "+-")
+
+	parts := strings.Split(value, ":")
+	if len(parts) != 3 {
+		return 0, fmt.Errorf("invalid sexagesimal value %q", value)
+	}
+	result := 0.0
+	scale := 1.0
+	for _, part := range parts {
+		v, err := strconv.ParseFloat(part, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid sexagesimal value %q: %v", value, err)
+		}
+		result += v / scale
+		scale *= 60
+	}
+	return sign * result, nil
+}
+
+// LoadStarCatalog reads a star catalogue in the format of the embedded bright star list,
+// one star per line with the fields
+//
+//	hr,name,ra (h:m:s),dec (d:m:s),vmag,pmra (mas/yr, times cos dec),pmdec (mas/yr)
+//
+// Lines starting with # are comments. Use it to load a larger catalogue than BrightStars.
+func LoadStarCatalog(r io.Reader) ([]CatalogStar, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = 7
+
+	var stars []CatalogStar
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var star CatalogStar
+		var errs [7]error
+		star.HR, errs[0] = strconv.Atoi(strings.TrimSpace(record[0]))
+		star.Name = strings.TrimSpace(record[1])
+		star.RightAscension, errs[2] = parse_sexagesimal(record[2])
+		star.RightAscension *= 15
+		star.Declination, errs[3] = parse_sexagesimal(record[3])
+		star.Magnitude, errs[4] = strconv.ParseFloat(strings.TrimSpace(record[4]), 64)
+		star.ProperMotionRA, errs[5] = strconv.ParseFloat(strings.TrimSpace(record[5]), 64)
+		star.ProperMotionDec, errs[6] = strconv.ParseFloat(strings.TrimSpace(record[6]), 64)
+		if err := errors.Join(errs[:]...); err != nil {
+			line, _ := reader.FieldPos(0)
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		stars = append(stars, star)
+	}
+	return stars, nil
+}
+
+// LoadYaleBrightStarCatalog reads the Yale Bright Star Catalogue, 5th revised edition, in
+// its fixed-width format as distributed by the CDS (catalogue V/50, file catalog).
+//
+// Entries without a J2000 position or a visual magnitude, such as the novae and clusters
+// of the original catalogue, are skipped.
+// Args:
+//
+//	r:         The catalogue file
+//	magnitude: The faintest visual magnitude to include, e.g. 6.5 for the naked eye
+//
+// Returns:
+//
+//	The stars in the order of the catalogue, named by their Bayer or Flamsteed designation.
+func LoadYaleBrightStarCatalog(r io.Reader, magnitude float64) ([]CatalogStar, error) {
+	var stars []CatalogStar
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		// the byte columns of the catalogue's ReadMe, counted from 1
+		field := func(from, to int) string {
+			if len(line) < to {
+				line += strings.Repeat(" ", to-len(line))
+			}
+			return strings.TrimSpace(line[from-1 : to])
+		}
+		if field(76, 77) == "" || field(103, 107) == "" {
+			continue
+		}
+
+		var star CatalogStar
+		var errs [5]error
+		star.HR, errs[0] = strconv.Atoi(field(1, 4))
+		star.Name = strings.Join(strings.Fields(field(5, 14)), " ")
+		star.RightAscension, errs[1] = parse_sexagesimal(field(76, 77) + ":" + field(78, 79) + ":" + field(80, 83))
+		star.RightAscension *= 15
+		star.Declination, errs[2] = parse_sexagesimal(field(84, 84) + field(85, 86) + ":" + field(87, 88) + ":" + field(89, 90))
+		star.Magnitude, errs[3] = strconv.ParseFloat(field(103, 107), 64)
+		pmra, pmdec := field(149, 154), field(155, 160)
+		if pmra != "" || pmdec != "" {
+			var errRA, errDec error
+			star.ProperMotionRA, errRA = strconv.ParseFloat(pmra, 64)
+			star.ProperMotionDec, errDec = strconv.ParseFloat(pmdec, 64)
+			errs[4] = errors.Join(errRA, errDec)
+			// the catalogue gives arc seconds per year
+			star.ProperMotionRA *= 1000
+			star.ProperMotionDec *= 1000
+		}
+		if err := errors.Join(errs[:]...); err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		if star.Magnitude <= magnitude {
+			stars = append(stars, star)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return stars, nil
+}
+
+// BrightStars returns the embedded list of the stars brighter than about magnitude 2.5
+// together with a few well-known fainter ones, sorted by brightness. Load the full Yale
+// Bright Star Catalogue with LoadYaleBrightStarCatalog for the fainter naked-eye stars.
+func BrightStars() []CatalogStar {
+	brightStarsOnce.Do(func() {
+		stars, err := LoadStarCatalog(strings.NewReader(brightStarsCSV))
+		if err != nil {
+			panic(fmt.Sprintf("embedded star catalogue: %v", err))
+		}
+		sort.SliceStable(stars, func(i, j int) bool { return stars[i].Magnitude < stars[j].Magnitude })
+		brightStars = stars
+	})
+	return append([]CatalogStar(nil), brightStars...)
+}
+
+// FindStar looks up a star of the embedded list by its name, ignoring case.
+func FindStar(name string) (CatalogStar, bool) {
+	for _, star := range BrightStars() {
+		if strings.EqualFold(star.Name, name) {
+			return star, true
+		}
+	}
+	return CatalogStar{}, false
+}
+
+// Calculate the mean position of a star referred to the equator and equinox of date
+// Args:
+//
+//	juliancentury: The Julian Century on the TT scale
+//
+// Returns:
+//
+//	The right ascension and declination in degrees.
+func star_mean_place(star CatalogStar, juliancentury float64) (float64, float64) {
+	years := juliancentury * 100
+	dec := star.Declination + star.ProperMotionDec*years/3.6e6
+	ra := star.RightAscension + star.ProperMotionRA*years/3.6e6/math.Cos(radians(star.Declination))
+
+	return precess_equatorial_from_j2000(juliancentury, ra, dec)
+}
+
+// Equatorial returns the apparent place of the star in degrees, see Apparent.
+func (s CatalogStar) Equatorial(dateandtime time.Time) (float64, float64) {
+	place := Apparent.Star(s, dateandtime)
+	return place.RightAscension, place.Declination
+}
+
+func (CatalogStar) StandardAltitude() float64 {
+	return StandardAltitudeStar
+}
+
+// StarPosition is the place of a star in the sky of an observer.
+type StarPosition struct {
+	Star      CatalogStar
+	Elevation float64
+	Azimuth   float64
+}
+
+// StarPositions calculates where stars stand in the sky of an observer.
+// Args:
+//
+//	stars:           The stars to calculate for, e.g. BrightStars()
+//	observer:        Observer to calculate for
+//	dateandtime:     The date and time to calculate for
+//	with_refraction: If True adjust the elevations to take refraction into account
+//
+// Returns:
+//
+//	The elevation and azimuth of every star in degrees, in the order of the stars.
+func StarPositions(stars []CatalogStar, observer Observer, dateandtime time.Time, with_refraction bool) []StarPosition {
+	lst := greenwich_apparent_sidereal_time(julian_datetime(dateandtime)) + observer.Longitude
+
+	positions := make([]StarPosition, len(stars))
+	for i, star := range stars {
+		ra, dec := star.Equatorial(dateandtime)
+		elevation, azimuth := equatorial_to_horizontal(observer.Latitude, lst-ra, dec)
+		if with_refraction {
+			elevation += refraction_at_zenith(90 - elevation)
+		}
+		positions[i] = StarPosition{Star: star, Elevation: elevation, Azimuth: azimuth}
+	}
+	return positions
+}
+
+// StarsAbove finds the stars that stand above an elevation in the sky of an observer.
+// Args:
+//
+//	stars:       The stars to search, e.g. BrightStars()
+//	observer:    Observer to calculate for
+//	dateandtime: The date and time to calculate for
+//	elevation:   The minimum apparent elevation in degrees
+//
+// Returns:
+//
+//	The positions of the stars, brightest first.
+func StarsAbove(stars []CatalogStar, observer Observer, dateandtime time.Time, elevation float64) []StarPosition {
+	var above []StarPosition
+	for _, position := range StarPositions(stars, observer, dateandtime, true) {
+		if position.Elevation >= elevation {
+			above = append(above, position)
+		}
+	}
+	sort.SliceStable(above, func(i, j int) bool { return above[i].Star.Magnitude < above[j].Star.Magnitude })
+	return above
+}
+
+var ErrNoHeliacalEvent = errors.New("star has no heliacal rising or setting in this year, at this location")
+
+// The depression of the sun in degrees at which a star of the given magnitude
+// becomes visible on the horizon, the arcus visionis
+func arcus_visionis(magnitude float64) float64 {
+	return 10.5 + 1.3*magnitude
+}
+
+// Find the first day of a year on which the visibility of a star at its rising or
+// setting changes to the wanted state.
+func heliacal_event(star CatalogStar, observer Observer, year int, loc *time.Location, rising, want bool) (time.Time, time.Time, error) {
+	depression := arcus_visionis(star.Magnitude)
+	visible := func(day time.Time) (time.Time, bool, bool) {
+		times, err := RiseTransitSet(star, observer, day)
+		if err != nil {
+			return time.Time{}, false, false
+		}
+		t := times.Set
+		if rising {
+			t = times.Rise
+		}
+		if t.IsZero() {
+			return t, false, false
+		}
+		return t, Elevation(observer, t, true) <= -depression, true
+	}
+
+	previousTime, previous, _ := visible(time.Date(year-1, 12, 31, 0, 0, 0, 0, loc))
+	for day := time.Date(year, 1, 1, 0, 0, 0, 0, loc); day.Year() == year; day = day.AddDate(0, 0, 1) {
+		t, current, ok := visible(day)
+		if !ok {
+			continue
+		}
+		if current == want && previous != want {
+			return t, previousTime, nil
+		}
+		previousTime, previous = t, current
+	}
+	return time.Time{}, time.Time{}, ErrNoHeliacalEvent
+}
+
+// HeliacalRising calculates the first morning of a year on which a star is seen rising
+// in the dawn before the sun, after it has been hidden in the sun's glare.
+//
+// The star counts as seen when the sun is lower than the arcus visionis for the star's
+// magnitude as the star rises, from about 8.5° for Sirius to 14° for stars of magnitude 2.5.
+// Args:
+//
+//	star:     The star to calculate for
+//	observer: Observer to calculate for
+//	year:     The year to search
+//	loc:      The timezone of the observer
+//
+// Returns:
+//
+//	The time at which the star rises on that morning, or ErrNoHeliacalEvent for stars
+//	that never set or never rise at the observer's latitude.
+func HeliacalRising(star CatalogStar, observer Observer, year int, loc *time.Location) (time.Time, error) {
+	t, _, err := heliacal_event(star, observer, year, loc, true, true)
+	return t, err
+}
+
+// HeliacalSetting calculates the last evening of a year on which a star is seen setting
+// in the dusk after the sun, before it disappears in the sun's glare.
+// Args:
+//
+//	star:     The star to calculate for
+//	observer: Observer to calculate for
+//	year:     The year to search
+//	loc:      The timezone of the observer
+//
+// Returns:
+//
+//	The time at which the star sets on that evening, or ErrNoHeliacalEvent for stars
+//	that never set or never rise at the observer's latitude.
+func HeliacalSetting(star CatalogStar, observer Observer, year int, loc *time.Location) (time.Time, error) {
+	_, t, err := heliacal_event(star, observer, year, loc, false, false)
+	return t, err
+}
@@ -0,0 +1,175 @@
+package celestial
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseSexagesimal(t *testing.T) {
+	var tests = []struct {
+		value   string
+		want    float64
+		wantErr bool
+	}{
+		{"06:45:08.92", 6.752478, false},
+		{"-16:42:58.0", -16.716111, false},
+		{"-00:17:56.7", -0.299083, false},
+		{"+89:15:50.8", 89.264111, false},
+		{"12:30", 0, true},
+		{"12:x:00", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parse_sexagesimal(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tt.wantErr)
+			}
+			almostEqualFloat(t, got, tt.want, 0.000001)
+		})
+	}
+}
+
+func TestLoadStarCatalog(t *testing.T) {
+	stars, err := LoadStarCatalog(strings.NewReader("# comment\n2491,Sirius,06:45:08.92,-16:42:58.0,-1.46,-546.01,-1223.07\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stars) != 1 || stars[0].HR != 2491 || stars[0].Name != "Sirius" {
+		t.Fatalf("got %+v", stars)
+	}
+	almostEqualFloat(t, stars[0].RightAscension, 101.287167, 0.000001)
+	almostEqualFloat(t, stars[0].ProperMotionDec, -1223.07, 0)
+
+	if _, err := LoadStarCatalog(strings.NewReader("1,Bad,06:45:08.92,-16:42:58.0,bright,0,0\n")); err == nil {
+		t.Errorf("expected an error for an invalid magnitude")
+	}
+}
+
+func TestLoadYaleBrightStarCatalog(t *testing.T) {
+	catalog := strings.Join([]string{
+		"   1                                                                       000509.9+451345             6.70                                         -0.012-0.018",
+		"  92                                                                                                   4.96",
+		"2491  9Alp CMa                                                             064508.9-164258            -1.46                                         -0.553-1.205",
+	}, "\n")
+
+	stars, err := LoadYaleBrightStarCatalog(strings.NewReader(catalog), 6.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stars) != 1 || stars[0].HR != 2491 || stars[0].Name != "9Alp CMa" {
+		t.Fatalf("got %+v", stars)
+	}
+	almostEqualFloat(t, stars[0].RightAscension, 101.287083, 0.000001)
+	almostEqualFloat(t, stars[0].Declination, -16.716111, 0.000001)
+	almostEqualFloat(t, stars[0].Magnitude, -1.46, 0)
+	almostEqualFloat(t, stars[0].ProperMotionRA, -553, 1e-9)
+	almostEqualFloat(t, stars[0].ProperMotionDec, -1205, 1e-9)
+
+	stars, err = LoadYaleBrightStarCatalog(strings.NewReader(catalog), 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stars) != 2 || stars[0].HR != 1 || stars[0].Name != "" {
+		t.Errorf("got %+v", stars)
+	}
+
+	if _, err := LoadYaleBrightStarCatalog(strings.NewReader(strings.Replace(catalog, "-1.46", "-1.x6", 1)), 6.5); err == nil {
+		t.Errorf("expected an error for an invalid magnitude")
+	}
+}
+
+func TestBrightStars(t *testing.T) {
+	stars := BrightStars()
+	if len(stars) < 90 {
+		t.Fatalf("got %d stars", len(stars))
+	}
+	if stars[0].Name != "Sirius" {
+		t.Errorf("brightest star is %v, want Sirius", stars[0].Name)
+	}
+	for i := 1; i < len(stars); i++ {
+		if stars[i].Magnitude < stars[i-1].Magnitude {
+			t.Errorf("%v is listed after the fainter %v", stars[i].Name, stars[i-1].Name)
+		}
+	}
+
+	if _, ok := FindStar("polaris"); !ok {
+		t.Errorf("Polaris not found")
+	}
+	if _, ok := FindStar("Vulcan"); ok {
+		t.Errorf("found a star that does not exist")
+	}
+}
+
+func TestStarMeanPlace(t *testing.T) {
+	// Meeus, Astronomical Algorithms, example 21.b, θ Persei on 2028 November 13.19 TD
+	star := CatalogStar{
+		RightAscension:  (2 + 44.0/60 + 11.986/3600) * 15,
+		Declination:     49 + 13.0/60 + 42.48/3600,
+		ProperMotionRA:  0.03425 * 15 * 1000 * math.Cos(radians(49+13.0/60+42.48/3600)),
+		ProperMotionDec: -89.5,
+	}
+	ra, dec := star_mean_place(star, jday_to_jcentury(2462088.69))
+	almostEqualFloat(t, ra, (2+46.0/60+11.331/3600)*15, 0.0001)
+	almostEqualFloat(t, dec, 49+20.0/60+54.54/3600, 0.0001)
+}
+
+func TestStarsAbove(t *testing.T) {
+	dateandtime := time.Date(2024, 1, 15, 22, 0, 0, 0, time.UTC)
+	above := StarsAbove(BrightStars(), london, dateandtime, 30)
+	if len(above) == 0 {
+		t.Fatal("no stars found")
+	}
+
+	names := map[string]bool{}
+	for i, position := range above {
+		names[position.Star.Name] = true
+		if position.Elevation < 30 {
+			t.Errorf("%v is at %.1f", position.Star.Name, position.Elevation)
+		}
+		if i > 0 && position.Star.Magnitude < above[i-1].Star.Magnitude {
+			t.Errorf("%v is listed after the fainter %v", position.Star.Name, above[i-1].Star.Name)
+		}
+	}
+	// Capella stands near the zenith on winter evenings, Sirius never gets higher than 22° in London
+	if !names["Capella"] || !names["Polaris"] || names["Sirius"] {
+		t.Errorf("unexpected stars %v", names)
+	}
+
+	// catalog stars rise and set like any other body
+	capella, _ := FindStar("Capella")
+	if _, err := RiseTransitSet(capella, london, dateandtime); err != ErrNeverSets {
+		t.Errorf("got %v, want Capella to be circumpolar", err)
+	}
+}
+
+func TestHeliacalRising(t *testing.T) {
+	// the heliacal rising of Sirius, which marked the Egyptian new year, now falls in early August at Cairo
+	cairo := Observer{Latitude: 30.04, Longitude: 31.24}
+	eet := time.FixedZone("EET", 2*3600)
+	sirius, _ := FindStar("Sirius")
+
+	rising, err := HeliacalRising(sirius, cairo, 2024, eet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rising.Month() != time.August || rising.Day() > 6 || rising.Hour() > 5 {
+		t.Errorf("got heliacal rising on %v", rising)
+	}
+
+	// Sirius is hidden for about 70 days
+	setting, err := HeliacalSetting(sirius, cairo, 2024, eet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if days := rising.Sub(setting).Hours() / 24; days < 55 || days > 80 {
+		t.Errorf("got heliacal setting on %v, %.0f days before the rising", setting, days)
+	}
+
+	polaris, _ := FindStar("Polaris")
+	if _, err := HeliacalRising(polaris, cairo, 2024, eet); err != ErrNoHeliacalEvent {
+		t.Errorf("got %v, want ErrNoHeliacalEvent for a circumpolar star", err)
+	}
+}
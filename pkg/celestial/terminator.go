@@ -0,0 +1,223 @@
+package celestial
+
+import (
+	"math"
+	"time"
+
+	"github.com/interimme/celestial/pkg/geojson"
+)
+
+// Degrees of bearing, and of distance along radial edges, between the points of a zone's outline
+const terminatorStep = 1.0
+
+// Calculate the point on the earth where the sun stands in the zenith
+// Returns:
+//
+//	The latitude and longitude in degrees, the longitude in the range (-180, 180].
+func subsolar_point(dateandtime time.Time) (float64, float64) {
+	jc := jday_to_jcentury(julian_datetime(dateandtime))
+
+	utc := dateandtime.UTC()
+	midnight := time.Date(utc.Year(), utc.Month(), utc.Day(), 0, 0, 0, 0, time.UTC)
+	minutes := utc.Sub(midnight).Minutes()
+
+	// the sun is over the meridian where the apparent solar time is noon
+	longitude := -(minutes + eq_of_time(jc) - 720) / 4
+	return sun_declination(jc), angle_difference(longitude, 0)
+}
+
+// Calculate the point reached by travelling along a great circle on a sphere
+// Args:
+//
+//	latitude, longitude: The starting point in degrees
+//	bearing:             The initial bearing in degrees clockwise from North
+//	distance:            The angular distance in degrees
+//
+// Returns:
+//
+//	The latitude and longitude of the destination in degrees.
+func great_circle_destination(latitude, longitude, bearing, distance float64) (float64, float64) {
+	sinphi, cosphi := math.Sincos(radians(latitude))
+	sind, cosd := math.Sincos(radians(distance))
+	sinb, cosb := math.Sincos(radians(bearing))
+
+	phi := math.Asin(clamp(sinphi*cosd+cosphi*sind*cosb, -1, 1))
+	lambda := radians(longitude) + math.Atan2(sinb*sind*cosphi, cosd-sinphi*math.Sin(phi))
+	return degrees(phi), angle_difference(degrees(lambda), 0)
+}
+
+// The outline of the part of an annulus around a center between two bearings.
+// The outline runs clockwise, along the outer edge with increasing bearing and
+// back along the inner edge.
+func zone_outline(latitude, longitude, inner, outer, from, to float64) []geojson.Position {
+	var points []geojson.Position
+	add := func(bearing, distance float64) {
+		lat, long := great_circle_destination(latitude, longitude, bearing, distance)
+		points = append(points, geojson.Position{long, lat})
+	}
+
+	for b := from; b < to; b += terminatorStep {
+		add(b, outer)
+	}
+	for d := outer; d > inner; d -= terminatorStep {
+		add(to, d)
+	}
+	for b := to; b > from; b -= terminatorStep {
+		add(b, inner)
+	}
+	for d := inner; d < outer; d += terminatorStep {
+		add(from, d)
+	}
+	return points
+}
+
+// The outline of a cap around a center, clockwise.
+func cap_outline(latitude, longitude, radius float64) []geojson.Position {
+	var points []geojson.Position
+	for b := 0.0; b < 360; b += terminatorStep {
+		lat, long := great_circle_destination(latitude, longitude, b, radius)
+		points = append(points, geojson.Position{long, lat})
+	}
+	return points
+}
+
+// Project a clockwise outline on the sphere onto a map in longitude and latitude.
+//
+// The outline is made continuous in longitude. An outline that goes around a pole
+// then ends a full turn away from where it started and is closed along the pole.
+// The result is cut at the antimeridian into polygons with counterclockwise
+// exterior rings, as GeoJSON expects.
+func map_polygons(outline []geojson.Position) [][][]geojson.Position {
+	if len(outline) < 3 {
+		return nil
+	}
+
+	points := make([]geojson.Position, 0, len(outline)+3)
+	points = append(points, outline...)
+	points = append(points, outline[0])
+	for i := 1; i < len(points); i++ {
+		points[i][0] = points[i-1][0] + angle_difference(points[i][0], points[i-1][0])
+	}
+
+	first, last := points[0], points[len(points)-1]
+	if turn := last[0] - first[0]; math.Abs(turn) > 180 {
+		// clockwise outlines go westward around the north pole
+		pole := 90.0
+		if turn > 0 {
+			pole = -90
+		}
+		points = append(points, geojson.Position{last[0], pole}, geojson.Position{first[0], pole})
+	} else {
+		points = points[:len(points)-1]
+	}
+
+	var polygons [][][]geojson.Position
+	for _, shift := range []float64{-360, 0, 360} {
+		shifted := make([]geojson.Position, len(points))
+		for i, p := range points {
+			shifted[i] = geojson.Position{p[0] + shift, p[1]}
+		}
+		clipped := clip_longitude(clip_longitude(shifted, -180, 1), 180, -1)
+		if math.Abs(ring_area(clipped)) < 1e-9 {
+			continue
+		}
+
+		// reversed to counterclockwise and rounded to about 10 cm
+		ring := make([]geojson.Position, 0, len(clipped)+1)
+		for i := len(clipped) - 1; i >= 0; i-- {
+			p := clipped[i]
+			ring = append(ring, geojson.Position{math.Round(p[0]*1e6) / 1e6, math.Round(p[1]*1e6) / 1e6})
+		}
+		ring = append(ring, ring[0])
+		polygons = append(polygons, [][]geojson.Position{ring})
+	}
+	return polygons
+}
+
+// Clip a polygon to the side of a meridian where sign*(longitude-limit) >= 0
+// using the Sutherland–Hodgman algorithm.
+func clip_longitude(points []geojson.Position, limit, sign float64) []geojson.Position {
+	inside := func(p geojson.Position) bool {
+		return sign*(p[0]-limit) >= 0
+	}
+
+	var clipped []geojson.Position
+	for i, current := range points {
+		previous := points[(i+len(points)-1)%len(points)]
+		if inside(current) != inside(previous) {
+			f := (limit - previous[0]) / (current[0] - previous[0])
+			clipped = append(clipped, geojson.Position{limit, previous[1] + f*(current[1]-previous[1])})
+		}
+		if inside(current) {
+			clipped = append(clipped, current)
+		}
+	}
+	return clipped
+}
+
+// The signed area of a ring by the shoelace formula, positive when counterclockwise
+func ring_area(points []geojson.Position) float64 {
+	area := 0.0
+	for i, p := range points {
+		q := points[(i+1)%len(points)]
+		area += p[0]*q[1] - q[0]*p[1]
+	}
+	return area / 2
+}
+
+// The twilight zones with the depressions of the sun that bound them
+var twilightZones = []struct {
+	name         string
+	inner, outer float64
+}{
+	{"civil twilight", 0, DepressionCivil},
+	{"nautical twilight", DepressionCivil, DepressionNautical},
+	{"astronomical twilight", DepressionNautical, DepressionAstronomical},
+}
+
+// Terminator calculates the day/night line and the twilight zones on the earth at an instant.
+//
+// The collection holds a feature for the sunlit hemisphere, one for each of the civil,
+// nautical and astronomical twilight zones and a point feature for the subsolar point.
+// Zones are MultiPolygons in longitude and latitude that are split at the antimeridian.
+// Their properties are the name of the zone and the range of the sun's elevation in it.
+// The zones are bounded by the geometric elevation of the sun, refraction is not included.
+// Args:
+//
+//	dateandtime: The instant to calculate for
+//
+// Returns:
+//
+//	A GeoJSON FeatureCollection.
+func Terminator(dateandtime time.Time) geojson.FeatureCollection {
+	latitude, longitude := subsolar_point(dateandtime)
+	antiLatitude, antiLongitude := -latitude, angle_difference(longitude+180, 0)
+
+	features := []geojson.Feature{
+		geojson.NewFeature(geojson.NewMultiPolygon(map_polygons(cap_outline(latitude, longitude, 90))), map[string]interface{}{
+			"name":         "day",
+			"minElevation": 0.0,
+			"maxElevation": 90.0,
+		}),
+	}
+
+	for _, zone := range twilightZones {
+		// the zone is a ring around the antisolar point, cut in two halves so
+		// that each half is a simple polygon
+		var polygons [][][]geojson.Position
+		for _, from := range []float64{90, 270} {
+			outline := zone_outline(antiLatitude, antiLongitude, 90-zone.outer, 90-zone.inner, from, from+180)
+			polygons = append(polygons, map_polygons(outline)...)
+		}
+		features = append(features, geojson.NewFeature(geojson.NewMultiPolygon(polygons), map[string]interface{}{
+			"name":         zone.name,
+			"minElevation": -zone.outer,
+			"maxElevation": -zone.inner,
+		}))
+	}
+
+	features = append(features, geojson.NewFeature(geojson.NewPoint(geojson.Position{longitude, latitude}), map[string]interface{}{
+		"name": "subsolar point",
+	}))
+	return geojson.NewFeatureCollection(features...)
+}
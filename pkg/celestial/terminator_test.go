@@ -0,0 +1,120 @@
+package celestial
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/interimme/celestial/pkg/geojson"
+)
+
+func TestSubsolarPoint(t *testing.T) {
+	var tests = []struct {
+		dateandtime   time.Time
+		wantLatitude  float64
+		wantLongitude float64
+	}{
+		// the equation of time is close to zero in mid April
+		{time.Date(2024, 4, 15, 12, 0, 0, 0, time.UTC), 10.05, 0.0},
+		{time.Date(2024, 6, 20, 20, 51, 0, 0, time.UTC), 23.44, -132.3},
+		{time.Date(2024, 12, 21, 0, 0, 0, 0, time.UTC), -23.44, 179.6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dateandtime.String(), func(t *testing.T) {
+			latitude, longitude := subsolar_point(tt.dateandtime)
+			almostEqualFloat(t, latitude, tt.wantLatitude, 0.1)
+			almostEqualFloat(t, longitude, tt.wantLongitude, 0.1)
+
+			zenith, _ := ZenithAndAzimuth(Observer{latitude, longitude, 0}, tt.dateandtime, false)
+			almostEqualFloat(t, zenith, 0, 0.01)
+		})
+	}
+}
+
+func TestGreatCircleDestination(t *testing.T) {
+	var tests = []struct {
+		name                        string
+		latitude, longitude         float64
+		bearing, distance           float64
+		wantLatitude, wantLongitude float64
+	}{
+		{"north", 0, 0, 0, 30, 30, 0},
+		{"east", 0, 170, 90, 20, 0, -170},
+		{"over the pole", 80, 10, 0, 20, 80, -170},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			latitude, longitude := great_circle_destination(tt.latitude, tt.longitude, tt.bearing, tt.distance)
+			almostEqualFloat(t, latitude, tt.wantLatitude, 1e-9)
+			almostEqualFloat(t, longitude, tt.wantLongitude, 1e-9)
+		})
+	}
+}
+
+// Even-odd rule point in polygon test for the exterior rings of a MultiPolygon
+func inMultiPolygon(geometry geojson.Geometry, p geojson.Position) bool {
+	inside := false
+	for _, polygon := range geometry.Coordinates.([][][]geojson.Position) {
+		ring := polygon[0]
+		for i := 1; i < len(ring); i++ {
+			a, b := ring[i-1], ring[i]
+			if (a[1] > p[1]) != (b[1] > p[1]) && p[0] < a[0]+(p[1]-a[1])*(b[0]-a[0])/(b[1]-a[1]) {
+				inside = !inside
+			}
+		}
+	}
+	return inside
+}
+
+func TestTerminator(t *testing.T) {
+	var tests = []time.Time{
+		time.Date(2024, 3, 20, 3, 6, 0, 0, time.UTC),
+		time.Date(2024, 6, 20, 20, 51, 0, 0, time.UTC),
+		time.Date(2024, 11, 5, 9, 30, 0, 0, time.UTC),
+		time.Date(2024, 12, 21, 0, 0, 0, 0, time.UTC),
+	}
+
+	for _, dateandtime := range tests {
+		t.Run(dateandtime.String(), func(t *testing.T) {
+			collection := Terminator(dateandtime)
+			if len(collection.Features) != 5 {
+				t.Fatalf("got %d features, want 5", len(collection.Features))
+			}
+
+			for _, feature := range collection.Features[:4] {
+				for _, polygon := range feature.Geometry.Coordinates.([][][]geojson.Position) {
+					if ring_area(polygon[0]) <= 0 {
+						t.Errorf("%v: exterior ring is not counterclockwise", feature.Properties["name"])
+					}
+				}
+			}
+
+			// every point on a grid must lie in the zone that matches the sun's elevation there
+			for latitude := -85.0; latitude <= 85; latitude += 5 {
+				for longitude := -177.5; longitude < 180; longitude += 5 {
+					elevation := Elevation(Observer{latitude, longitude, 0}, dateandtime, false)
+					p := geojson.Position{longitude, latitude}
+					for _, feature := range collection.Features[:4] {
+						min := feature.Properties["minElevation"].(float64)
+						max := feature.Properties["maxElevation"].(float64)
+						if math.Abs(elevation-min) < 0.5 || math.Abs(elevation-max) < 0.5 {
+							continue
+						}
+						want := elevation > min && elevation < max
+						if got := inMultiPolygon(feature.Geometry, p); got != want {
+							t.Errorf("%v at %.1f, %.1f with elevation %.2f: got %v, want %v",
+								feature.Properties["name"], latitude, longitude, elevation, got, want)
+						}
+					}
+				}
+			}
+
+			subsolar := collection.Features[4].Geometry.Coordinates.(geojson.Position)
+			latitude, longitude := subsolar_point(dateandtime)
+			almostEqualFloat(t, subsolar[0], longitude, 1e-9)
+			almostEqualFloat(t, subsolar[1], latitude, 1e-9)
+		})
+	}
+}
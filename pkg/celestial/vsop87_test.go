@@ -0,0 +1,45 @@
+package celestial
+
+import (
+	"testing"
+)
+
+func TestEarthHeliocentric(t *testing.T) {
+	var tests = []struct {
+		julianday     float64
+		wantLongitude float64
+		wantLatitude  float64
+		wantDistance  float64
+	}{
+		// Meeus, Astronomical Algorithms, example 25.b
+		{2448908.5, 19.907372, -0.000179, 0.99760775},
+		// Meeus, Astronomical Algorithms, example 33.a
+		{2448976.5, 88.35704, 0.00014, 0.983824},
+	}
+
+	for _, tt := range tests {
+		longitude, latitude, distance := earth_heliocentric(jday_to_jcentury(tt.julianday))
+		almostEqualFloat(t, longitude, tt.wantLongitude, 0.000005)
+		almostEqualFloat(t, latitude, tt.wantLatitude, 0.000005)
+		almostEqualFloat(t, distance, tt.wantDistance, 0.0000005)
+	}
+}
+
+func TestSunApparentPosition(t *testing.T) {
+	// Meeus, Astronomical Algorithms, example 25.b
+	jc := jday_to_jcentury(2448908.5)
+	longitude, latitude, distance := sun_apparent_position(jc)
+	almostEqualFloat(t, longitude, 199+54.0/60+21.818/3600, 0.05/3600)
+	almostEqualFloat(t, latitude, 0.62/3600, 0.05/3600)
+	almostEqualFloat(t, distance, 0.99760775, 0.0000005)
+
+	ra, dec := ecliptic_to_equatorial(longitude, latitude, obliquity_correction(jc))
+	almostEqualFloat(t, ra, (13+13.0/60+30.749/3600)*15, 0.05/3600*15)
+	almostEqualFloat(t, dec, -(7 + 47.0/60 + 1.94/3600), 0.05/3600)
+
+	dateandtime := terrestrialTime(1992, 10, 13)
+	almostEqualFloat(t, SunApparentLongitude(dateandtime), longitude, 0.000001)
+	gotRa, gotDec := Sun.Equatorial(dateandtime)
+	almostEqualFloat(t, gotRa, ra, 0.000001)
+	almostEqualFloat(t, gotDec, dec, 0.000001)
+}
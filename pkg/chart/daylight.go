@@ -0,0 +1,328 @@
+package chart
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/interimme/celestial/pkg/celestial"
+	"github.com/logrusorgru/aurora/v3"
+)
+
+// DaylightBand classifies the light at a time of day by the elevation of the sun.
+type DaylightBand int
+
+const (
+	// Night is when the sun is more than 18 degrees below the horizon
+	Night DaylightBand = iota
+	// AstronomicalTwilight is when the sun is between 18 and 12 degrees below the horizon
+	AstronomicalTwilight
+	// NauticalTwilight is when the sun is between 12 and 6 degrees below the horizon
+	NauticalTwilight
+	// BlueHour is the part of civil twilight when the sun is between 6 and 4 degrees below the horizon
+	BlueHour
+	// CivilGoldenHour is the rest of civil twilight, which belongs to the golden hour
+	CivilGoldenHour
+	// GoldenHour is the part of the day when the sun is less than 6 degrees above the horizon
+	GoldenHour
+	// Daylight is when the sun is more than 6 degrees above the horizon
+	Daylight
+)
+
+var bandNames = [...]string{
+	Night:                "Night",
+	AstronomicalTwilight: "Astronomical twilight",
+	NauticalTwilight:     "Nautical twilight",
+	BlueHour:             "Civil twilight, blue hour",
+	CivilGoldenHour:      "Civil twilight, golden hour",
+	GoldenHour:           "Golden hour",
+	Daylight:             "Daylight",
+}
+
+func (b DaylightBand) String() string {
+	if b < 0 || int(b) >= len(bandNames) {
+		return fmt.Sprintf("DaylightBand(%d)", int(b))
+	}
+	return bandNames[b]
+}
+
+// The lower elevation limit of every band above Night
+var bandElevations = [...]float64{
+	AstronomicalTwilight: -celestial.DepressionAstronomical,
+	NauticalTwilight:     -celestial.DepressionNautical,
+	BlueHour:             -celestial.DepressionCivil,
+	CivilGoldenHour:      -4,
+	GoldenHour:           -0.833,
+	Daylight:             6,
+}
+
+func bandAt(elevation float64) DaylightBand {
+	band := Night
+	for b := AstronomicalTwilight; b <= Daylight; b++ {
+		if elevation >= bandElevations[b] {
+			band = b
+		}
+	}
+	return band
+}
+
+// DaylightSpan is an interval of a single band.
+type DaylightSpan struct {
+	Band  DaylightBand
+	Start time.Time
+	End   time.Time
+}
+
+// DaylightDay is the sequence of bands from midnight to midnight of a local day.
+type DaylightDay struct {
+	Date  time.Time
+	Spans []DaylightSpan
+}
+
+// BandAt returns the band at the given time of the day.
+func (d DaylightDay) BandAt(t time.Time) DaylightBand {
+	for _, span := range d.Spans {
+		if !t.Before(span.Start) && t.Before(span.End) {
+			return span.Band
+		}
+	}
+	if len(d.Spans) > 0 {
+		return d.Spans[len(d.Spans)-1].Band
+	}
+	return Night
+}
+
+// DaylightChart holds the bands of light for every day of a year.
+type DaylightChart struct {
+	Observer celestial.Observer
+	Year     int
+	Location *time.Location
+	Days     []DaylightDay
+}
+
+type daylightEvent struct {
+	t    time.Time
+	band DaylightBand
+}
+
+// The events at which the sun enters a band, rising or setting, for the given UTC-based date
+func daylightEvents(observer celestial.Observer, date time.Time) []daylightEvent {
+	var events []daylightEvent
+	add := func(t time.Time, err error, band DaylightBand) {
+		if err == nil {
+			events = append(events, daylightEvent{t: t, band: band})
+		}
+	}
+	pair := func(start, end time.Time, err error, startBand, endBand DaylightBand) {
+		if err == nil {
+			events = append(events, daylightEvent{t: start, band: startBand}, daylightEvent{t: end, band: endBand})
+		}
+	}
+
+	t, err := celestial.Dawn(observer, date, celestial.DepressionAstronomical)
+	add(t, err, AstronomicalTwilight)
+	t, err = celestial.Dawn(observer, date, celestial.DepressionNautical)
+	add(t, err, NauticalTwilight)
+	start, end, err := celestial.BlueHour(observer, date, celestial.SunDirectionRising)
+	pair(start, end, err, BlueHour, CivilGoldenHour)
+	t, err = celestial.Sunrise(observer, date)
+	add(t, err, GoldenHour)
+	_, end, err = celestial.GoldenHour(observer, date, celestial.SunDirectionRising)
+	add(end, err, Daylight)
+
+	start, _, err = celestial.GoldenHour(observer, date, celestial.SunDirectionSetting)
+	add(start, err, GoldenHour)
+	t, err = celestial.Sunset(observer, date)
+	add(t, err, CivilGoldenHour)
+	start, end, err = celestial.BlueHour(observer, date, celestial.SunDirectionSetting)
+	pair(start, end, err, BlueHour, NauticalTwilight)
+	t, err = celestial.Dusk(observer, date, celestial.DepressionNautical)
+	add(t, err, AstronomicalTwilight)
+	t, err = celestial.Dusk(observer, date, celestial.DepressionAstronomical)
+	add(t, err, Night)
+
+	return events
+}
+
+func newDaylightDay(observer celestial.Observer, day time.Time) DaylightDay {
+	loc := day.Location()
+	start := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, loc)
+	end := start.AddDate(0, 0, 1)
+
+	// the event functions work on UTC days, which may be offset from the local day
+	var events []daylightEvent
+	for offset := -1; offset <= 1; offset++ {
+		utc := time.Date(day.Year(), day.Month(), day.Day()+offset, 12, 0, 0, 0, time.UTC)
+		for _, event := range daylightEvents(observer, utc) {
+			if !event.t.Before(start) && event.t.Before(end) {
+				events = append(events, event)
+			}
+		}
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].t.Before(events[j].t)
+	})
+
+	d := DaylightDay{Date: start}
+	current := DaylightSpan{Band: bandAt(celestial.Elevation(observer, start, true)), Start: start}
+	for _, event := range events {
+		if event.band == current.Band {
+			continue
+		}
+		current.End = event.t.In(loc)
+		d.Spans = append(d.Spans, current)
+		current = DaylightSpan{Band: event.band, Start: event.t.In(loc)}
+	}
+	current.End = end
+	d.Spans = append(d.Spans, current)
+	return d
+}
+
+// NewDaylightChart calculates the bands of light for every day of a year.
+// Args:
+//
+//	observer: Observer to calculate for
+//	year:     The year to calculate for
+//	loc:      The timezone of the observer; days run from local midnight to midnight
+//
+// Returns:
+//
+//	The chart data with one entry per day.
+func NewDaylightChart(observer celestial.Observer, year int, loc *time.Location) DaylightChart {
+	chart := DaylightChart{Observer: observer, Year: year, Location: loc}
+	for day := time.Date(year, 1, 1, 0, 0, 0, 0, loc); day.Year() == year; day = day.AddDate(0, 0, 1) {
+		chart.Days = append(chart.Days, newDaylightDay(observer, day))
+	}
+	return chart
+}
+
+// hours since local midnight as shown on a wall clock, so that daylight saving time shows as a jump
+func clockHours(t time.Time) float64 {
+	return float64(t.Hour()) + float64(t.Minute())/60 + float64(t.Second())/3600
+}
+
+var bandFills = [...]string{
+	Night:                "#0b1a33",
+	AstronomicalTwilight: "#1f3566",
+	NauticalTwilight:     "#3d5a99",
+	BlueHour:             "#5b86d6",
+	CivilGoldenHour:      "#d9825b",
+	GoldenHour:           "#f2b441",
+	Daylight:             "#fde9a6",
+}
+
+const (
+	daylightDayWidth = 2
+	daylightHour     = 20
+	daylightLegend   = 150
+)
+
+// SVG renders the chart as an SVG document with the day of the year on
+// the horizontal and the local time on the vertical axis.
+func (c DaylightChart) SVG(w io.Writer) error {
+	width := chartMargin*2 + daylightDayWidth*len(c.Days) + daylightLegend
+	height := chartMargin*2 + daylightHour*24
+	s := newSVG(width, height)
+
+	top := float64(chartMargin)
+	for i, day := range c.Days {
+		x := float64(chartMargin + i*daylightDayWidth)
+		for _, span := range day.Spans {
+			y1 := top + clockHours(span.Start)*daylightHour
+			y2 := top + 24*daylightHour
+			if span.End.Before(day.Date.AddDate(0, 0, 1)) {
+				y2 = top + clockHours(span.End)*daylightHour
+			}
+			if y2 > y1 {
+				s.rect(x, y1, daylightDayWidth, y2-y1, fmt.Sprintf(`fill="%s"`, bandFills[span.Band]))
+			}
+		}
+	}
+
+	right := float64(chartMargin + daylightDayWidth*len(c.Days))
+	for hour := 0; hour <= 24; hour += 3 {
+		y := top + float64(hour*daylightHour)
+		s.line(chartMargin, y, right, y, gridStyle)
+		s.text(chartMargin-4, y+4, "end", fmt.Sprintf("%02d:00", hour))
+	}
+	for i, day := range c.Days {
+		if day.Date.Day() == 1 {
+			x := float64(chartMargin + i*daylightDayWidth)
+			s.line(x, top, x, top+24*daylightHour, gridStyle)
+			s.text(x+daylightDayWidth*15, top+24*daylightHour+16, "middle", day.Date.Format("Jan"))
+		}
+	}
+
+	for b := Night; b <= Daylight; b++ {
+		y := top + float64(int(b)*daylightHour)
+		s.rect(right+12, y, 12, 12, fmt.Sprintf(`fill="%s" stroke="#333333" stroke-width="0.5"`, bandFills[b]))
+		s.text(right+30, y+10, "start", b.String())
+	}
+
+	title := fmt.Sprintf("Daylight %d at %.4f, %.4f (%s)", c.Year, c.Observer.Latitude, c.Observer.Longitude, c.Location)
+	return s.write(w, title)
+}
+
+var bandColors = [...]aurora.Value{
+	Night:                aurora.BgIndex(17, " "),
+	AstronomicalTwilight: aurora.BgGray(8, " "),
+	NauticalTwilight:     aurora.BgGray(15, " "),
+	BlueHour:             aurora.BgIndex(111, " "),
+	CivilGoldenHour:      aurora.BgIndex(208, " "),
+	GoldenHour:           aurora.BgIndex(214, " "),
+	Daylight:             aurora.BgIndex(226, " "),
+}
+
+// Terminal renders the chart with colored cells for a terminal. Every column
+// stands for a few days and every row for half an hour.
+// Args:
+//
+//	w:       Where to write the chart to
+//	columns: The number of columns to use for the days of the year
+func (c DaylightChart) Terminal(w io.Writer, columns int) error {
+	if columns <= 0 || len(c.Days) == 0 {
+		return fmt.Errorf("invalid number of columns %d", columns)
+	}
+	if columns > len(c.Days) {
+		columns = len(c.Days)
+	}
+
+	var b strings.Builder
+	for row := 0; row < 48; row++ {
+		if row%6 == 0 {
+			fmt.Fprintf(&b, "%02d:00 ", row/2)
+		} else {
+			b.WriteString("      ")
+		}
+		for column := 0; column < columns; column++ {
+			day := c.Days[column*len(c.Days)/columns]
+			t := day.Date.Add(time.Duration(row)*30*time.Minute + 15*time.Minute)
+			b.WriteString(bandColors[day.BandAt(t)].String())
+		}
+		b.WriteString("\n")
+	}
+
+	// month labels below the columns
+	labels := []rune(strings.Repeat(" ", columns+6))
+	for column := 0; column < columns; column++ {
+		day := c.Days[column*len(c.Days)/columns]
+		previous := day.Date.AddDate(0, 0, -len(c.Days)/columns)
+		if column == 0 || previous.Month() != day.Date.Month() {
+			name := []rune(day.Date.Format("Jan"))
+			if column+6+len(name) <= len(labels) {
+				copy(labels[column+6:], name)
+			}
+		}
+	}
+	b.WriteString(strings.TrimRight(string(labels), " "))
+	b.WriteString("\n\n")
+
+	for band := Night; band <= Daylight; band++ {
+		fmt.Fprintf(&b, "%v %v\n", bandColors[band], band)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,139 @@
+package chart
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/interimme/celestial/pkg/celestial"
+)
+
+func spanOf(day DaylightDay, band DaylightBand) (DaylightSpan, bool) {
+	for _, span := range day.Spans {
+		if span.Band == band {
+			return span, true
+		}
+	}
+	return DaylightSpan{}, false
+}
+
+func TestNewDaylightChart(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Skip(err)
+	}
+	chart := NewDaylightChart(london, 2024, loc)
+
+	if got := len(chart.Days); got != 366 {
+		t.Fatalf("len(Days) = %v, want 366", got)
+	}
+
+	for _, day := range chart.Days {
+		for i, span := range day.Spans {
+			if span.End.Before(span.Start) {
+				t.Fatalf("%v: span %v ends before it starts", day.Date, span)
+			}
+			if i > 0 && !span.Start.Equal(day.Spans[i-1].End) {
+				t.Fatalf("%v: spans are not contiguous", day.Date)
+			}
+		}
+	}
+
+	// it never gets fully dark in London around the June solstice
+	june := chart.Days[172]
+	if _, ok := spanOf(june, Night); ok {
+		t.Errorf("%v: unexpected night", june.Date)
+	}
+	december := chart.Days[355]
+	if _, ok := spanOf(december, Night); !ok {
+		t.Errorf("%v: no night", december.Date)
+	}
+
+	// sunrise moves an hour later when daylight saving time starts on March 31
+	before, _ := spanOf(chart.Days[89], GoldenHour)
+	after, _ := spanOf(chart.Days[90], GoldenHour)
+	if jump := clockHours(after.Start) - clockHours(before.Start); jump < 0.9 || jump > 1 {
+		t.Errorf("sunrise moved by %v hours", jump)
+	}
+	sunrise, _ := celestial.Sunrise(london, chart.Days[90].Date)
+	almostEqualDuration(t, after.Start.Sub(sunrise), 0, time.Second)
+}
+
+func almostEqualDuration(t *testing.T, d1, d2, allowedDiff time.Duration) {
+	t.Helper()
+	if diff := d1 - d2; diff > allowedDiff || diff < -allowedDiff {
+		t.Fatalf("diff: %v, d1 %v, d2 %v", diff, d1, d2)
+	}
+}
+
+func TestNewDaylightChartFarFromUTC(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skip(err)
+	}
+	tokyo := celestial.Observer{Latitude: 35.6762, Longitude: 139.6503}
+	chart := NewDaylightChart(tokyo, 2024, loc)
+
+	// the sun rises around 4:25 local time in June, which is the previous day in UTC
+	sunrise, ok := spanOf(chart.Days[172], GoldenHour)
+	if !ok {
+		t.Fatalf("no sunrise")
+	}
+	if h := clockHours(sunrise.Start); h < 4 || h > 5 {
+		t.Fatalf("sunrise at %v", sunrise.Start)
+	}
+}
+
+func TestDaylightChartSVG(t *testing.T) {
+	chart := NewDaylightChart(london, 2024, time.UTC)
+
+	var buf bytes.Buffer
+	if err := chart.SVG(&buf); err != nil {
+		t.Fatal(err)
+	}
+	decoder := xml.NewDecoder(&buf)
+	rects := 0
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("invalid SVG: %v", err)
+		}
+		if start, ok := token.(xml.StartElement); ok && start.Name.Local == "rect" {
+			rects++
+		}
+	}
+	if rects < len(chart.Days)*5 {
+		t.Errorf("%d rects", rects)
+	}
+}
+
+func TestDaylightChartTerminal(t *testing.T) {
+	chart := NewDaylightChart(london, 2024, time.UTC)
+
+	var buf bytes.Buffer
+	if err := chart.Terminal(&buf, 73); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(buf.String(), "\n")
+	if !strings.HasPrefix(lines[0], "00:00 ") || !strings.HasPrefix(lines[24], "12:00 ") {
+		t.Errorf("unexpected hour labels:\n%s", buf.String())
+	}
+	if !strings.HasPrefix(lines[48], "      Jan") {
+		t.Errorf("unexpected month labels %q", lines[48])
+	}
+	for band := Night; band <= Daylight; band++ {
+		if !strings.Contains(buf.String(), band.String()) {
+			t.Errorf("legend misses %v", band)
+		}
+	}
+
+	if err := chart.Terminal(io.Discard, 0); err == nil {
+		t.Errorf("expected an error for zero columns")
+	}
+}
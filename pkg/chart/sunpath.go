@@ -0,0 +1,331 @@
+package chart
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"github.com/interimme/celestial/pkg/celestial"
+)
+
+// Projection selects how azimuth and elevation are mapped onto a sun-path diagram.
+type Projection int
+
+const (
+	// Polar draws the sky as a disk with the zenith in the centre and North at the top
+	Polar Projection = iota
+	// Cartesian draws azimuth on the horizontal and elevation on the vertical axis
+	Cartesian
+)
+
+// Point is a position of the sun at a moment in time.
+type Point struct {
+	Time      time.Time
+	Azimuth   float64
+	Elevation float64
+}
+
+// Curve is a labelled sequence of sun positions. Points below the horizon are kept
+// so that a curve can be split where the sun sets and rises again.
+type Curve struct {
+	Label  string
+	Points []Point
+}
+
+// SunPathDiagram holds the sun positions that make up a sun-path diagram.
+type SunPathDiagram struct {
+	Observer celestial.Observer
+	Year     int
+	// Days are the daily paths of the sun on the equinoxes, solstices and the 21st of every month
+	Days []Curve
+	// Hours are the analemmas traced by the sun at each full hour of standard time
+	Hours []Curve
+}
+
+const (
+	pathStep      = 5 * time.Minute
+	analemmaStep  = 7
+	sunPathWidth  = 720
+	sunPathHeight = 720
+	cartesianW    = 960
+	cartesianH    = 480
+	chartMargin   = 40
+)
+
+// Find the day in the given year and location on which the sun's apparent longitude reaches the given value.
+func dayAtSolarLongitude(year int, longitude float64, loc *time.Location) time.Time {
+	day := time.Date(year, 1, 1, 0, 0, 0, 0, loc)
+	previous := celestial.SolarCoordinates(day).ApparentLongitude
+	for i := 0; i < 366; i++ {
+		next := day.AddDate(0, 0, 1)
+		current := celestial.SolarCoordinates(next).ApparentLongitude
+		// the longitude grows by about a degree a day and wraps at 360
+		if math.Mod(current-longitude+360, 360) < math.Mod(previous-longitude+360, 360) {
+			return day
+		}
+		day, previous = next, current
+	}
+	return day
+}
+
+// The standard time of the location, i.e. its offset without daylight saving time.
+func standardTimeZone(year int, loc *time.Location) *time.Location {
+	_, january := time.Date(year, 1, 1, 0, 0, 0, 0, loc).Zone()
+	_, july := time.Date(year, 7, 1, 0, 0, 0, 0, loc).Zone()
+	offset := january
+	if july < offset {
+		offset = july
+	}
+	return time.FixedZone(fmt.Sprintf("UTC%+03d:%02d", offset/3600, abs(offset%3600)/60), offset)
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func sunPoint(observer celestial.Observer, t time.Time) Point {
+	zenith, azimuth := celestial.ZenithAndAzimuth(observer, t, true)
+	return Point{Time: t, Azimuth: azimuth, Elevation: 90 - zenith}
+}
+
+func dayCurve(observer celestial.Observer, label string, day time.Time) Curve {
+	start := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	end := start.AddDate(0, 0, 1)
+
+	curve := Curve{Label: label}
+	for t := start; !t.After(end); t = t.Add(pathStep) {
+		curve.Points = append(curve.Points, sunPoint(observer, t))
+	}
+	return curve
+}
+
+// NewSunPathDiagram samples the sun's position for a sun-path diagram.
+// Args:
+//
+//	observer: Observer to calculate for
+//	year:     The year to draw
+//	loc:      The timezone of the observer. Hour lines use its standard time.
+//
+// Returns:
+//
+//	The daily paths and the hourly analemmas of the sun.
+func NewSunPathDiagram(observer celestial.Observer, year int, loc *time.Location) SunPathDiagram {
+	diagram := SunPathDiagram{Observer: observer, Year: year}
+
+	seasons := []struct {
+		label     string
+		longitude float64
+	}{
+		{"March equinox", 0},
+		{"June solstice", 90},
+		{"September equinox", 180},
+		{"December solstice", 270},
+	}
+	for _, season := range seasons {
+		day := dayAtSolarLongitude(year, season.longitude, loc)
+		diagram.Days = append(diagram.Days, dayCurve(observer, fmt.Sprintf("%s (%s)", season.label, day.Format("Jan 2")), day))
+	}
+	for month := time.January; month <= time.December; month++ {
+		day := time.Date(year, month, 21, 12, 0, 0, 0, loc)
+		diagram.Days = append(diagram.Days, dayCurve(observer, day.Format("Jan 2"), day))
+	}
+
+	standard := standardTimeZone(year, loc)
+	for hour := 0; hour < 24; hour++ {
+		curve := Curve{Label: fmt.Sprintf("%02d:00", hour)}
+		visible := false
+		for day := 0; day <= 365; day += analemmaStep {
+			p := sunPoint(observer, time.Date(year, 1, 1+day, hour, 0, 0, 0, standard))
+			visible = visible || p.Elevation > 0
+			curve.Points = append(curve.Points, p)
+		}
+		// close the figure of eight
+		curve.Points = append(curve.Points, curve.Points[0])
+		if visible {
+			diagram.Hours = append(diagram.Hours, curve)
+		}
+	}
+	return diagram
+}
+
+// Split a curve into the runs of points that lie above the horizon.
+func visibleRuns(points []Point) [][]Point {
+	var runs [][]Point
+	var run []Point
+	for _, p := range points {
+		if p.Elevation < 0 {
+			if len(run) > 1 {
+				runs = append(runs, run)
+			}
+			run = nil
+			continue
+		}
+		run = append(run, p)
+	}
+	if len(run) > 1 {
+		runs = append(runs, run)
+	}
+	return runs
+}
+
+// the mapping of a sun position to the drawing
+type projector func(azimuth, elevation float64) (float64, float64)
+
+func polarProjector() projector {
+	cx, cy := sunPathWidth/2.0, sunPathHeight/2.0
+	r := sunPathWidth/2.0 - chartMargin
+	return func(azimuth, elevation float64) (float64, float64) {
+		d := r * (90 - elevation) / 90
+		a := azimuth * math.Pi / 180
+		return cx + d*math.Sin(a), cy - d*math.Cos(a)
+	}
+}
+
+func cartesianProjector() projector {
+	w := float64(cartesianW - 2*chartMargin)
+	h := float64(cartesianH - 2*chartMargin)
+	return func(azimuth, elevation float64) (float64, float64) {
+		return chartMargin + w*azimuth/360, chartMargin + h*(90-elevation)/90
+	}
+}
+
+// Split a run where it wraps around North so that the cartesian chart
+// does not get lines across its whole width.
+func splitAtNorth(run []Point) [][]Point {
+	var parts [][]Point
+	start := 0
+	for i := 1; i < len(run); i++ {
+		if math.Abs(run[i].Azimuth-run[i-1].Azimuth) > 180 {
+			parts = append(parts, run[start:i])
+			start = i
+		}
+	}
+	return append(parts, run[start:])
+}
+
+func drawCurve(s *svg, project projector, projection Projection, curve Curve, style string) {
+	for _, run := range visibleRuns(curve.Points) {
+		parts := [][]Point{run}
+		if projection == Cartesian {
+			parts = splitAtNorth(run)
+		}
+		for _, part := range parts {
+			xs := make([]float64, len(part))
+			ys := make([]float64, len(part))
+			for i, p := range part {
+				xs[i], ys[i] = project(p.Azimuth, p.Elevation)
+			}
+			s.polyline(xs, ys, style)
+		}
+	}
+}
+
+// the highest visible point of a curve, used to place its label
+func highest(curve Curve) (Point, bool) {
+	best := Point{Elevation: -90}
+	for _, p := range curve.Points {
+		if p.Elevation > best.Elevation {
+			best = p
+		}
+	}
+	return best, best.Elevation > 0
+}
+
+const (
+	gridStyle     = `stroke="#bbbbbb" stroke-width="0.5"`
+	horizonStyle  = `stroke="#333333" stroke-width="1"`
+	seasonStyle   = `stroke="#e07b00" stroke-width="2"`
+	monthStyle    = `stroke="#f2b441" stroke-width="1"`
+	analemmaStyle = `stroke="#3b6ea5" stroke-width="1" stroke-dasharray="4 2"`
+)
+
+var compassPoints = []string{"N", "NE", "E", "SE", "S", "SW", "W", "NW"}
+
+// SVG renders the diagram as an SVG document.
+// Args:
+//
+//	w:          Where to write the document to
+//	projection: Either Polar or Cartesian
+func (d SunPathDiagram) SVG(w io.Writer, projection Projection) error {
+	var s *svg
+	var project projector
+
+	switch projection {
+	case Polar:
+		s = newSVG(sunPathWidth, sunPathHeight)
+		project = polarProjector()
+		cx, cy := project(0, 90)
+		r := sunPathWidth/2.0 - chartMargin
+		for elevation := 0; elevation < 90; elevation += 10 {
+			style := gridStyle
+			if elevation == 0 {
+				style = horizonStyle
+			}
+			s.circle(cx, cy, r*float64(90-elevation)/90, `fill="none" `+style)
+			if elevation > 0 {
+				x, y := project(0, float64(elevation))
+				s.text(x+2, y-2, "start", fmt.Sprintf("%d°", elevation))
+			}
+		}
+		for i, label := range compassPoints {
+			azimuth := float64(i) * 45
+			x, y := project(azimuth, 0)
+			s.line(cx, cy, x, y, gridStyle)
+			lx, ly := project(azimuth, -6)
+			s.text(lx, ly+4, "middle", label)
+		}
+	case Cartesian:
+		s = newSVG(cartesianW, cartesianH)
+		project = cartesianProjector()
+		for elevation := 0; elevation <= 90; elevation += 10 {
+			style := gridStyle
+			if elevation == 0 {
+				style = horizonStyle
+			}
+			x1, y := project(0, float64(elevation))
+			x2, _ := project(360, float64(elevation))
+			s.line(x1, y, x2, y, style)
+			s.text(x1-4, y+4, "end", fmt.Sprintf("%d°", elevation))
+		}
+		for i := 0; i <= len(compassPoints); i++ {
+			azimuth := float64(i) * 45
+			x, y1 := project(azimuth, 90)
+			_, y2 := project(azimuth, 0)
+			s.line(x, y1, x, y2, gridStyle)
+			s.text(x, y2+16, "middle", compassPoints[i%len(compassPoints)])
+		}
+	default:
+		return fmt.Errorf("unknown projection %v", projection)
+	}
+
+	for _, curve := range d.Hours {
+		drawCurve(s, project, projection, curve, analemmaStyle)
+		if p, ok := highest(curve); ok {
+			x, y := project(p.Azimuth, p.Elevation)
+			s.text(x, y-4, "middle", curve.Label)
+		}
+	}
+	for i, curve := range d.Days {
+		style := monthStyle
+		if i < 4 {
+			style = seasonStyle
+		}
+		drawCurve(s, project, projection, curve, style)
+	}
+	for i, curve := range d.Days {
+		if i >= 4 {
+			break
+		}
+		if p, ok := highest(curve); ok {
+			x, y := project(p.Azimuth, p.Elevation)
+			s.text(x, y+14, "middle", curve.Label)
+		}
+	}
+
+	title := fmt.Sprintf("Sun path %d at %.4f, %.4f", d.Year, d.Observer.Latitude, d.Observer.Longitude)
+	return s.write(w, title)
+}
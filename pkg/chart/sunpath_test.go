@@ -0,0 +1,98 @@
+package chart
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/interimme/celestial/pkg/celestial"
+)
+
+var london = celestial.Observer{Latitude: 51.509865, Longitude: -0.118092}
+
+func TestNewSunPathDiagram(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Skip(err)
+	}
+	diagram := NewSunPathDiagram(london, 2024, loc)
+
+	if got := len(diagram.Days); got != 16 {
+		t.Fatalf("len(Days) = %v, want 16", got)
+	}
+	wantSeasons := []string{"Mar 20", "Jun 20", "Sep 22", "Dec 21"}
+	for i, want := range wantSeasons {
+		if !strings.Contains(diagram.Days[i].Label, want) {
+			t.Errorf("Days[%d].Label = %q, want it to contain %q", i, diagram.Days[i].Label, want)
+		}
+	}
+
+	// the sun culminates at 90 - latitude + 23.44 on the June solstice
+	june, _ := highest(diagram.Days[1])
+	if math.Abs(june.Elevation-(90-london.Latitude+23.44)) > 0.2 {
+		t.Errorf("June solstice culmination = %v", june.Elevation)
+	}
+
+	// the sun is up at noon but not at midnight all year round in London
+	var labels []string
+	for _, curve := range diagram.Hours {
+		labels = append(labels, curve.Label)
+	}
+	joined := strings.Join(labels, " ")
+	if !strings.Contains(joined, "12:00") || strings.Contains(joined, "00:00") {
+		t.Errorf("hour lines = %v", joined)
+	}
+	for _, curve := range diagram.Hours {
+		first, last := curve.Points[0], curve.Points[len(curve.Points)-1]
+		if first != last {
+			t.Errorf("analemma %v is not closed", curve.Label)
+		}
+	}
+}
+
+func TestStandardTimeZone(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	_, offset := time.Date(2024, 7, 1, 12, 0, 0, 0, standardTimeZone(2024, loc)).Zone()
+	if offset != -5*3600 {
+		t.Fatalf("offset = %v, want -5h", offset)
+	}
+}
+
+func TestSunPathDiagramSVG(t *testing.T) {
+	diagram := NewSunPathDiagram(london, 2024, time.UTC)
+
+	for _, projection := range []Projection{Polar, Cartesian} {
+		var buf bytes.Buffer
+		if err := diagram.SVG(&buf, projection); err != nil {
+			t.Fatal(err)
+		}
+		decoder := xml.NewDecoder(&buf)
+		polylines := 0
+		for {
+			token, err := decoder.Token()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("invalid SVG: %v", err)
+			}
+			if start, ok := token.(xml.StartElement); ok && start.Name.Local == "polyline" {
+				polylines++
+			}
+		}
+		if polylines < len(diagram.Days)+len(diagram.Hours) {
+			t.Errorf("projection %v: %d polylines", projection, polylines)
+		}
+	}
+
+	if err := diagram.SVG(io.Discard, Projection(42)); err == nil {
+		t.Fatalf("expected an error for an unknown projection")
+	}
+}
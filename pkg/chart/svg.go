@@ -0,0 +1,58 @@
+package chart
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// svg collects the elements of an SVG document before it is written out.
+type svg struct {
+	width, height int
+	body          bytes.Buffer
+}
+
+func newSVG(width, height int) *svg {
+	return &svg{width: width, height: height}
+}
+
+func (s *svg) line(x1, y1, x2, y2 float64, style string) {
+	fmt.Fprintf(&s.body, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" %s/>`+"\n", x1, y1, x2, y2, style)
+}
+
+func (s *svg) circle(cx, cy, r float64, style string) {
+	fmt.Fprintf(&s.body, `<circle cx="%.1f" cy="%.1f" r="%.1f" %s/>`+"\n", cx, cy, r, style)
+}
+
+func (s *svg) rect(x, y, width, height float64, style string) {
+	fmt.Fprintf(&s.body, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" %s/>`+"\n", x, y, width, height, style)
+}
+
+func (s *svg) polyline(xs, ys []float64, style string) {
+	if len(xs) < 2 {
+		return
+	}
+	points := make([]string, len(xs))
+	for i := range xs {
+		points[i] = fmt.Sprintf("%.1f,%.1f", xs[i], ys[i])
+	}
+	fmt.Fprintf(&s.body, `<polyline points="%s" fill="none" %s/>`+"\n", strings.Join(points, " "), style)
+}
+
+func (s *svg) text(x, y float64, anchor string, text string) {
+	fmt.Fprintf(&s.body, `<text x="%.1f" y="%.1f" text-anchor="%s">%s</text>`+"\n", x, y, anchor, escape(text))
+}
+
+// write outputs the complete document. The root element has no XML prolog so that
+// the output can be embedded in HTML reports as is.
+func (s *svg) write(w io.Writer, title string) error {
+	_, err := fmt.Fprintf(w, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="11">`+"\n"+
+		"<title>%s</title>\n"+`<rect width="100%%" height="100%%" fill="white"/>`+"\n%s</svg>\n",
+		s.width, s.height, s.width, s.height, escape(title), s.body.String())
+	return err
+}
+
+func escape(text string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;").Replace(text)
+}
@@ -0,0 +1,28 @@
+package chart
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSVG(t *testing.T) {
+	s := newSVG(100, 50)
+	s.polyline([]float64{1}, []float64{1}, "")
+	s.text(10, 20, "middle", `<a & "b">`)
+
+	var buf bytes.Buffer
+	if err := s.write(&buf, "x<y"); err != nil {
+		t.Fatal(err)
+	}
+	got := buf.String()
+
+	for _, want := range []string{`width="100" height="50"`, "<title>x&lt;y</title>", "&lt;a &amp; &quot;b&quot;&gt;"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("SVG does not contain %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "<polyline") {
+		t.Errorf("a single point must not produce a polyline")
+	}
+}
@@ -0,0 +1,66 @@
+// Package geojson contains the few GeoJSON types (RFC 7946) that the celestial
+// package produces. They marshal to GeoJSON with encoding/json and need no other dependencies.
+package geojson
+
+// Position is a longitude and latitude in degrees, in this order as GeoJSON requires.
+type Position [2]float64
+
+// Geometry is a GeoJSON geometry object.
+type Geometry struct {
+	Type        string      `json:"type"`
+	Coordinates interface{} `json:"coordinates"`
+}
+
+// NewPoint creates a Point geometry.
+func NewPoint(position Position) Geometry {
+	return Geometry{Type: "Point", Coordinates: position}
+}
+
+// NewLineString creates a LineString geometry.
+func NewLineString(positions []Position) Geometry {
+	return Geometry{Type: "LineString", Coordinates: positions}
+}
+
+// NewMultiLineString creates a MultiLineString geometry.
+func NewMultiLineString(lines [][]Position) Geometry {
+	return Geometry{Type: "MultiLineString", Coordinates: lines}
+}
+
+// NewPolygon creates a Polygon geometry. The first ring is the exterior, any further rings are holes.
+func NewPolygon(rings [][]Position) Geometry {
+	return Geometry{Type: "Polygon", Coordinates: rings}
+}
+
+// NewMultiPolygon creates a MultiPolygon geometry.
+func NewMultiPolygon(polygons [][][]Position) Geometry {
+	return Geometry{Type: "MultiPolygon", Coordinates: polygons}
+}
+
+// Feature is a geometry with properties.
+type Feature struct {
+	Type       string                 `json:"type"`
+	Geometry   Geometry               `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// NewFeature creates a Feature. A nil map of properties marshals to an empty object.
+func NewFeature(geometry Geometry, properties map[string]interface{}) Feature {
+	if properties == nil {
+		properties = map[string]interface{}{}
+	}
+	return Feature{Type: "Feature", Geometry: geometry, Properties: properties}
+}
+
+// FeatureCollection is a list of features.
+type FeatureCollection struct {
+	Type     string    `json:"type"`
+	Features []Feature `json:"features"`
+}
+
+// NewFeatureCollection creates a FeatureCollection from the given features.
+func NewFeatureCollection(features ...Feature) FeatureCollection {
+	if features == nil {
+		features = []Feature{}
+	}
+	return FeatureCollection{Type: "FeatureCollection", Features: features}
+}
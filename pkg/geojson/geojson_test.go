@@ -0,0 +1,35 @@
+package geojson
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMarshal(t *testing.T) {
+	var tests = []struct {
+		name  string
+		value interface{}
+		want  string
+	}{
+		{"point", NewPoint(Position{-0.12, 51.5}), `{"type":"Point","coordinates":[-0.12,51.5]}`},
+		{"polygon", NewPolygon([][]Position{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}}),
+			`{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,0]]]}`},
+		{"feature", NewFeature(NewPoint(Position{1, 2}), nil),
+			`{"type":"Feature","geometry":{"type":"Point","coordinates":[1,2]},"properties":{}}`},
+		{"empty collection", NewFeatureCollection(), `{"type":"FeatureCollection","features":[]}`},
+		{"collection", NewFeatureCollection(NewFeature(NewPoint(Position{1, 2}), map[string]interface{}{"name": "a"})),
+			`{"type":"FeatureCollection","features":[{"type":"Feature","geometry":{"type":"Point","coordinates":[1,2]},"properties":{"name":"a"}}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
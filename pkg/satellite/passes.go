@@ -0,0 +1,258 @@
+package satellite
+
+import (
+	"math"
+	"time"
+
+	"github.com/interimme/celestial/pkg/celestial"
+)
+
+// WGS84 ellipsoid for the observer, in km
+const (
+	wgs84SemiMajorAxis = 6378.137
+	wgs84Flattening    = 1 / 298.257223563
+)
+
+// Rotate a TEME vector to the earth fixed frame, ignoring the polar motion
+func teme_to_ecef(r [3]float64, t time.Time) [3]float64 {
+	sing, cosg := math.Sincos(gstime(julian_day(t)))
+	return [3]float64{cosg*r[0] + sing*r[1], -sing*r[0] + cosg*r[1], r[2]}
+}
+
+// Calculate the earth fixed position of an observer in km
+func observer_ecef(observer celestial.Observer) [3]float64 {
+	const e2 = wgs84Flattening * (2 - wgs84Flattening)
+	sinphi, cosphi := math.Sincos(observer.Latitude * math.Pi / 180)
+	sinlambda, coslambda := math.Sincos(observer.Longitude * math.Pi / 180)
+	n := wgs84SemiMajorAxis / math.Sqrt(1-e2*sinphi*sinphi)
+	h := observer.Elevation / 1000
+	return [3]float64{
+		(n + h) * cosphi * coslambda,
+		(n + h) * cosphi * sinlambda,
+		(n*(1-e2) + h) * sinphi,
+	}
+}
+
+// Observation is the direction and distance of a satellite seen by an observer.
+type Observation struct {
+	Elevation float64
+	Azimuth   float64
+	// Range is the distance from the observer in km
+	Range float64
+}
+
+// Observe calculates where an observer sees the satellite. The elevation is geometric,
+// without refraction.
+// Returns:
+//
+//	The elevation and the azimuth clockwise from North in degrees and the range in km.
+func (s *Satellite) Observe(observer celestial.Observer, t time.Time) (Observation, error) {
+	r, _, err := s.Propagate(t)
+	if err != nil {
+		return Observation{}, err
+	}
+	sat := teme_to_ecef(r, t)
+	obs := observer_ecef(observer)
+	rx, ry, rz := sat[0]-obs[0], sat[1]-obs[1], sat[2]-obs[2]
+
+	// rotate the range vector to the south, east and zenith directions of the observer
+	sinphi, cosphi := math.Sincos(observer.Latitude * math.Pi / 180)
+	sinlambda, coslambda := math.Sincos(observer.Longitude * math.Pi / 180)
+	south := sinphi*coslambda*rx + sinphi*sinlambda*ry - cosphi*rz
+	east := -sinlambda*rx + coslambda*ry
+	zenith := cosphi*coslambda*rx + cosphi*sinlambda*ry + sinphi*rz
+
+	distance := math.Sqrt(rx*rx + ry*ry + rz*rz)
+	elevation := math.Asin(zenith/distance) * 180 / math.Pi
+	azimuth := math.Atan2(east, -south) * 180 / math.Pi
+	if azimuth < 0 {
+		azimuth += 360
+	}
+	return Observation{Elevation: elevation, Azimuth: azimuth, Range: distance}, nil
+}
+
+// Geodetic calculates the point on the WGS84 ellipsoid below the satellite.
+// Returns:
+//
+//	The point and the height of the satellite above it in km.
+func (s *Satellite) Geodetic(t time.Time) (celestial.GroundPoint, float64, error) {
+	const e2 = wgs84Flattening * (2 - wgs84Flattening)
+	r, _, err := s.Propagate(t)
+	if err != nil {
+		return celestial.GroundPoint{}, 0, err
+	}
+	p := teme_to_ecef(r, t)
+	rho := math.Hypot(p[0], p[1])
+	longitude := math.Atan2(p[1], p[0])
+
+	latitude := math.Atan2(p[2], rho*(1-e2))
+	var n float64
+	for i := 0; i < 5; i++ {
+		sinphi := math.Sin(latitude)
+		n = wgs84SemiMajorAxis / math.Sqrt(1-e2*sinphi*sinphi)
+		latitude = math.Atan2(p[2]+n*e2*sinphi, rho)
+	}
+	height := rho/math.Cos(latitude) - n
+
+	point := celestial.GroundPoint{Time: t, Latitude: latitude * 180 / math.Pi, Longitude: longitude * 180 / math.Pi}
+	return point, height, nil
+}
+
+// Check whether a position lies in the shadow of the earth, modelled as a cylinder
+// Args:
+//
+//	r:   The geocentric position in km
+//	sun: The geocentric direction of the sun as a unit vector
+func in_shadow(r, sun [3]float64) bool {
+	along := r[0]*sun[0] + r[1]*sun[1] + r[2]*sun[2]
+	if along > 0 {
+		return false
+	}
+	x, y, z := r[0]-along*sun[0], r[1]-along*sun[1], r[2]-along*sun[2]
+	return math.Sqrt(x*x+y*y+z*z) < earthRadius
+}
+
+// Sunlit checks whether the sun shines on the satellite or the satellite is in the shadow
+// of the earth.
+func (s *Satellite) Sunlit(t time.Time) (bool, error) {
+	r, _, err := s.Propagate(t)
+	if err != nil {
+		return false, err
+	}
+	ra, dec := celestial.Sun.Equatorial(t)
+	sinra, cosra := math.Sincos(ra * math.Pi / 180)
+	sindec, cosdec := math.Sincos(dec * math.Pi / 180)
+	return !in_shadow(r, [3]float64{cosdec * cosra, cosdec * sinra, sindec}), nil
+}
+
+// Pass is a passage of a satellite over an observer.
+type Pass struct {
+	// AOS is the acquisition of signal, when the satellite rises above the minimum elevation
+	AOS        time.Time
+	AOSAzimuth float64
+	// Culmination is the time of the highest elevation
+	Culmination  time.Time
+	MaxElevation float64
+	MaxAzimuth   float64
+	// LOS is the loss of signal, when the satellite sinks below the minimum elevation
+	LOS        time.Time
+	LOSAzimuth float64
+	// Visible is true when the satellite is sunlit at some time of the pass while the sun
+	// is lower than civil twilight for the observer, so that it can be seen by eye
+	Visible bool
+}
+
+// Time between the samples of the elevation when searching for passes
+func (s *Satellite) pass_step() time.Duration {
+	if s.deep {
+		return 10 * time.Minute
+	}
+	return time.Minute
+}
+
+// Find the time at which a function changes sign between two times, to the second
+func bisect_time(f func(time.Time) float64, lo, hi time.Time) time.Time {
+	flo := f(lo)
+	for hi.Sub(lo) > time.Second {
+		mid := lo.Add(hi.Sub(lo) / 2)
+		if fmid := f(mid); (fmid < 0) == (flo < 0) {
+			lo, flo = mid, fmid
+		} else {
+			hi = mid
+		}
+	}
+	return lo.Add(hi.Sub(lo) / 2).Round(time.Second)
+}
+
+// Passes predicts the passes of the satellite over an observer.
+// Args:
+//
+//	observer:     Observer to calculate for
+//	from, to:     The time range to search
+//	minElevation: The elevation in degrees above which the satellite counts as in view,
+//	              0 for the horizon or e.g. 10 for a radio link
+//
+// Returns:
+//
+//	The passes in the range. A pass that is in progress at the start or the end of the
+//	range begins or ends there. Passes shorter than about a minute may be missed.
+func (s *Satellite) Passes(observer celestial.Observer, from, to time.Time, minElevation float64) ([]Pass, error) {
+	var observeErr error
+	elevation := func(t time.Time) float64 {
+		observation, err := s.Observe(observer, t)
+		if err != nil {
+			observeErr = err
+			return -90 - minElevation
+		}
+		return observation.Elevation - minElevation
+	}
+	azimuth := func(t time.Time) float64 {
+		observation, _ := s.Observe(observer, t)
+		return observation.Azimuth
+	}
+
+	var passes []Pass
+	var aos time.Time
+	step := s.pass_step()
+	previous, above := from, elevation(from) >= 0
+	if above {
+		aos = from
+	}
+	for t := from.Add(step); ; t = t.Add(step) {
+		if t.After(to) {
+			t = to
+		}
+		current := elevation(t) >= 0
+		if observeErr != nil {
+			return passes, observeErr
+		}
+		switch {
+		case current && !above:
+			aos = bisect_time(elevation, previous, t)
+		case !current && above:
+			passes = append(passes, s.pass(observer, aos, bisect_time(elevation, previous, t), elevation, azimuth))
+		}
+		if !t.Before(to) {
+			if current {
+				passes = append(passes, s.pass(observer, aos, to, elevation, azimuth))
+			}
+			break
+		}
+		previous, above = t, current
+	}
+	return passes, observeErr
+}
+
+// Fill in the details of a pass between its AOS and LOS
+func (s *Satellite) pass(observer celestial.Observer, aos, los time.Time, elevation, azimuth func(time.Time) float64) Pass {
+	// the elevation has a single maximum during a pass, find it with a golden section search
+	const ratio = 0.6180339887498949
+	lo, hi := aos, los
+	for hi.Sub(lo) > time.Second {
+		d := time.Duration(float64(hi.Sub(lo)) * ratio)
+		if elevation(hi.Add(-d)) < elevation(lo.Add(d)) {
+			lo = hi.Add(-d)
+		} else {
+			hi = lo.Add(d)
+		}
+	}
+	culmination := lo.Add(hi.Sub(lo) / 2).Round(time.Second)
+	observation, _ := s.Observe(observer, culmination)
+
+	visible := false
+	for t := aos; !t.After(los) && !visible; t = t.Add(10 * time.Second) {
+		sunlit, err := s.Sunlit(t)
+		visible = err == nil && sunlit && celestial.Elevation(observer, t, true) < -celestial.DepressionCivil
+	}
+
+	return Pass{
+		AOS:          aos,
+		AOSAzimuth:   azimuth(aos),
+		Culmination:  culmination,
+		MaxElevation: observation.Elevation,
+		MaxAzimuth:   observation.Azimuth,
+		LOS:          los,
+		LOSAzimuth:   azimuth(los),
+		Visible:      visible,
+	}
+}
@@ -0,0 +1,152 @@
+package satellite
+
+import (
+	"testing"
+	"time"
+
+	"github.com/interimme/celestial/pkg/celestial"
+)
+
+func TestObserveZenith(t *testing.T) {
+	s, err := New(parse(t, "", leo))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for hours := 0; hours < 24; hours += 5 {
+		dateandtime := s.TLE.Epoch.Add(time.Duration(hours) * time.Hour)
+		point, height, err := s.Geodetic(dateandtime)
+		if err != nil {
+			t.Fatal(err)
+		}
+		observation, err := s.Observe(celestial.Observer{Latitude: point.Latitude, Longitude: point.Longitude}, dateandtime)
+		if err != nil {
+			t.Fatal(err)
+		}
+		almostEqualFloat(t, observation.Elevation, 90, 1e-6)
+		almostEqualFloat(t, observation.Range, height, 1e-6)
+		if height < 300 || height > 500 {
+			t.Fatalf("height %f", height)
+		}
+	}
+}
+
+func TestInShadow(t *testing.T) {
+	sun := [3]float64{1, 0, 0}
+	var tests = []struct {
+		r    [3]float64
+		want bool
+	}{
+		{[3]float64{7000, 0, 0}, false},
+		{[3]float64{-7000, 0, 0}, true},
+		{[3]float64{0, 7000, 0}, false},
+		{[3]float64{-7000, 6000, 0}, true},
+		{[3]float64{-7000, 0, 6500}, false},
+		{[3]float64{-42164, 0, 0}, true},
+	}
+	for _, test := range tests {
+		if got := in_shadow(test.r, sun); got != test.want {
+			t.Fatalf("%v: got %v", test.r, got)
+		}
+	}
+}
+
+func TestPasses(t *testing.T) {
+	s, err := New(parse(t, "", leo))
+	if err != nil {
+		t.Fatal(err)
+	}
+	observer := celestial.Observer{Latitude: 52.52, Longitude: 13.40, Elevation: 34}
+	from := s.TLE.Epoch
+	to := from.Add(48 * time.Hour)
+	passes, err := s.Passes(observer, from, to, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(passes) < 4 {
+		t.Fatalf("got %d passes", len(passes))
+	}
+
+	for _, pass := range passes {
+		if !pass.AOS.Before(pass.Culmination) || !pass.Culmination.Before(pass.LOS) || pass.LOS.Sub(pass.AOS) > 15*time.Minute {
+			t.Fatalf("pass %v %v %v", pass.AOS, pass.Culmination, pass.LOS)
+		}
+		aos, _ := s.Observe(observer, pass.AOS)
+		los, _ := s.Observe(observer, pass.LOS)
+		almostEqualFloat(t, aos.Elevation, 10, 0.1)
+		almostEqualFloat(t, los.Elevation, 10, 0.1)
+		almostEqualFloat(t, aos.Azimuth, pass.AOSAzimuth, 1e-9)
+
+		// no sample of the pass is higher than the culmination
+		for t1 := pass.AOS; t1.Before(pass.LOS); t1 = t1.Add(5 * time.Second) {
+			observation, _ := s.Observe(observer, t1)
+			if observation.Elevation > pass.MaxElevation+1e-3 {
+				t.Fatalf("%v: elevation %f above %f", t1, observation.Elevation, pass.MaxElevation)
+			}
+		}
+
+		// the sun hardly moves during a pass, so it is below civil twilight at one end of a
+		// visible pass
+		dark := min(celestial.Elevation(observer, pass.AOS, true), celestial.Elevation(observer, pass.LOS, true))
+		if pass.Visible && dark > -celestial.DepressionCivil+0.1 {
+			t.Fatalf("pass at %v is visible with the sun at %f", pass.Culmination, dark)
+		}
+	}
+
+	// a pass in progress at the start of the range begins there
+	inProgress := passes[0].Culmination
+	clipped, err := s.Passes(observer, inProgress, inProgress.Add(time.Hour), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(clipped) == 0 || !clipped[0].AOS.Equal(inProgress) {
+		t.Fatalf("clipped %v", clipped)
+	}
+	almostEqualTime(t, clipped[0].LOS, passes[0].LOS, time.Second)
+}
+
+func TestPassesGeostationary(t *testing.T) {
+	// a geostationary satellite over the meridian of the observer never sets
+	epoch := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	s, err := New(TLE{Epoch: epoch, MeanMotion: 1.00273791})
+	if err != nil {
+		t.Fatal(err)
+	}
+	point, _, err := s.Geodetic(epoch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	observer := celestial.Observer{Latitude: 45, Longitude: point.Longitude}
+	passes, err := s.Passes(observer, epoch, epoch.Add(24*time.Hour), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(passes) != 1 || !passes[0].AOS.Equal(epoch) || !passes[0].LOS.Equal(epoch.Add(24*time.Hour)) {
+		t.Fatalf("passes %v", passes)
+	}
+	almostEqualFloat(t, passes[0].MaxElevation, 38.2, 0.5)
+	almostEqualFloat(t, passes[0].MaxAzimuth, 180, 1)
+
+	// it is eclipsed around midnight in the weeks around the equinoxes only
+	var tests = []struct {
+		date     time.Time
+		eclipsed bool
+	}{
+		{time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC), true},
+		{time.Date(2024, 6, 21, 0, 0, 0, 0, time.UTC), false},
+		{time.Date(2024, 9, 22, 0, 0, 0, 0, time.UTC), true},
+		{time.Date(2024, 12, 21, 0, 0, 0, 0, time.UTC), false},
+	}
+	for _, test := range tests {
+		eclipsed := false
+		for minutes := 0; minutes < 24*60; minutes += 10 {
+			sunlit, err := s.Sunlit(test.date.Add(time.Duration(minutes) * time.Minute))
+			if err != nil {
+				t.Fatal(err)
+			}
+			eclipsed = eclipsed || !sunlit
+		}
+		if eclipsed != test.eclipsed {
+			t.Fatalf("%v: eclipsed %v", test.date, eclipsed)
+		}
+	}
+}
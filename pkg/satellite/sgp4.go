@@ -0,0 +1,439 @@
+package satellite
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// WGS72 constants used by the SGP4 model, which the element sets are fitted with
+const (
+	earthRadius = 6378.135 // km
+	earthMu     = 398600.8 // km³/s²
+	j2          = 0.001082616
+	j3          = -0.00000253881
+	j4          = -0.00000165597
+	j3oj2       = j3 / j2
+	twoPi       = 2 * math.Pi
+	x2o3        = 2.0 / 3.0
+)
+
+// xke is the square root of the gravitational parameter in earth radii³ per minute²
+var xke = 60 / math.Sqrt(earthRadius*earthRadius*earthRadius/earthMu)
+
+var (
+	ErrEccentricity = errors.New("mean eccentricity is out of range")
+	ErrMeanMotion   = errors.New("mean motion is negative")
+	ErrPerturbed    = errors.New("perturbed eccentricity is out of range")
+	ErrSemiLatus    = errors.New("semi-latus rectum is negative")
+	ErrDecayed      = errors.New("satellite has decayed")
+)
+
+// Satellite holds the initialised SGP4 model of an element set.
+//
+// The model follows Vallado et al., Revisiting Spacetrack Report #3, AIAA 2006-6753, in
+// the improved operation mode. Satellites with a period of 225 minutes or more are
+// propagated with the deep space SDP4 extensions for the sun, the moon and resonances.
+type Satellite struct {
+	TLE TLE
+
+	// epoch in Julian days
+	jdEpoch float64
+
+	isimp    bool
+	deep     bool
+	irez     int
+	bstar    float64
+	ecco     float64
+	argpo    float64
+	inclo    float64
+	mo       float64
+	no       float64
+	nodeo    float64
+	gsto     float64
+	aycof    float64
+	con41    float64
+	cc1      float64
+	cc4      float64
+	cc5      float64
+	d2       float64
+	d3       float64
+	d4       float64
+	delmo    float64
+	eta      float64
+	argpdot  float64
+	omgcof   float64
+	sinmao   float64
+	t2cof    float64
+	t3cof    float64
+	t4cof    float64
+	t5cof    float64
+	x1mth2   float64
+	x7thm1   float64
+	mdot     float64
+	nodedot  float64
+	xlcof    float64
+	xmcof    float64
+	nodecf   float64
+	deepTerm deepSpace
+}
+
+// Terms of the deep space model
+type deepSpace struct {
+	e3, ee2, peo, pgho, pho, pinco, plo                 float64
+	se2, se3, sgh2, sgh3, sgh4, sh2, sh3, si2, si3      float64
+	sl2, sl3, sl4, xgh2, xgh3, xgh4, xh2, xh3, xi2, xi3 float64
+	xl2, xl3, xl4, zmol, zmos                           float64
+	d2201, d2211, d3210, d3222, d4410, d4422            float64
+	d5220, d5232, d5421, d5433                          float64
+	dedt, del1, del2, del3, didt, dmdt, dnodt, domdt    float64
+	xfact, xlamo                                        float64
+}
+
+// The Julian day of a time
+func julian_day(t time.Time) float64 {
+	return float64(t.UnixNano())/86400e9 + 2440587.5
+}
+
+// Calculate the Greenwich mean sidereal time in radians
+func gstime(jdut1 float64) float64 {
+	tut1 := (jdut1 - 2451545.0) / 36525.0
+	seconds := -6.2e-6*tut1*tut1*tut1 + 0.093104*tut1*tut1 + (876600.0*3600+8640184.812866)*tut1 + 67310.54841
+	theta := math.Mod(seconds*math.Pi/180/240, twoPi)
+	if theta < 0 {
+		theta += twoPi
+	}
+	return theta
+}
+
+// New initialises the SGP4 model of an element set.
+func New(tle TLE) (*Satellite, error) {
+	const deg = math.Pi / 180
+	// revolutions per day to radians per minute
+	const xpdotp = 1440 / twoPi
+
+	s := &Satellite{
+		TLE:     tle,
+		jdEpoch: julian_day(tle.Epoch),
+		bstar:   tle.BStar,
+		ecco:    tle.Eccentricity,
+		argpo:   tle.ArgumentOfPerigee * deg,
+		inclo:   tle.Inclination * deg,
+		mo:      tle.MeanAnomaly * deg,
+		no:      tle.MeanMotion / xpdotp,
+		nodeo:   tle.RightAscension * deg,
+	}
+	if err := s.init(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Initialise the model, see sgp4init and initl of Vallado et al.
+func (s *Satellite) init() error {
+	if s.ecco < 0 || s.ecco >= 1 {
+		return ErrEccentricity
+	}
+	if s.no <= 0 {
+		return ErrMeanMotion
+	}
+	epoch := s.jdEpoch - 2433281.5
+
+	ss := 78.0/earthRadius + 1.0
+	qzms2t := math.Pow((120.0-78.0)/earthRadius, 4)
+
+	// un-kozai the mean motion
+	eccsq := s.ecco * s.ecco
+	omeosq := 1.0 - eccsq
+	rteosq := math.Sqrt(omeosq)
+	cosio := math.Cos(s.inclo)
+	cosio2 := cosio * cosio
+
+	ak := math.Pow(xke/s.no, x2o3)
+	d1 := 0.75 * j2 * (3.0*cosio2 - 1.0) / (rteosq * omeosq)
+	del := d1 / (ak * ak)
+	adel := ak * (1.0 - del*del - del*(1.0/3.0+134.0*del*del/81.0))
+	del = d1 / (adel * adel)
+	s.no = s.no / (1.0 + del)
+
+	ao := math.Pow(xke/s.no, x2o3)
+	sinio := math.Sin(s.inclo)
+	po := ao * omeosq
+	con42 := 1.0 - 5.0*cosio2
+	s.con41 = -con42 - cosio2 - cosio2
+	posq := po * po
+	rp := ao * (1.0 - s.ecco)
+	s.gsto = gstime(epoch + 2433281.5)
+
+	s.isimp = rp < 220.0/earthRadius+1.0
+	sfour := ss
+	qzms24 := qzms2t
+	perige := (rp - 1.0) * earthRadius
+
+	// for perigees below 156 km, s and qoms2t are altered
+	if perige < 156.0 {
+		sfour = perige - 78.0
+		if perige < 98.0 {
+			sfour = 20.0
+		}
+		qzms24 = math.Pow((120.0-sfour)/earthRadius, 4)
+		sfour = sfour/earthRadius + 1.0
+	}
+	pinvsq := 1.0 / posq
+
+	tsi := 1.0 / (ao - sfour)
+	s.eta = ao * s.ecco * tsi
+	etasq := s.eta * s.eta
+	eeta := s.ecco * s.eta
+	psisq := math.Abs(1.0 - etasq)
+	coef := qzms24 * math.Pow(tsi, 4)
+	coef1 := coef / math.Pow(psisq, 3.5)
+	cc2 := coef1 * s.no * (ao*(1.0+1.5*etasq+eeta*(4.0+etasq)) +
+		0.375*j2*tsi/psisq*s.con41*(8.0+3.0*etasq*(8.0+etasq)))
+	s.cc1 = s.bstar * cc2
+	cc3 := 0.0
+	if s.ecco > 1.0e-4 {
+		cc3 = -2.0 * coef * tsi * j3oj2 * s.no * sinio / s.ecco
+	}
+	s.x1mth2 = 1.0 - cosio2
+	s.cc4 = 2.0 * s.no * coef1 * ao * omeosq *
+		(s.eta*(2.0+0.5*etasq) + s.ecco*(0.5+2.0*etasq) -
+			j2*tsi/(ao*psisq)*(-3.0*s.con41*(1.0-2.0*eeta+etasq*(1.5-0.5*eeta))+
+				0.75*s.x1mth2*(2.0*etasq-eeta*(1.0+etasq))*math.Cos(2.0*s.argpo)))
+	s.cc5 = 2.0 * coef1 * ao * omeosq * (1.0 + 2.75*(etasq+eeta) + eeta*etasq)
+	cosio4 := cosio2 * cosio2
+	temp1 := 1.5 * j2 * pinvsq * s.no
+	temp2 := 0.5 * temp1 * j2 * pinvsq
+	temp3 := -0.46875 * j4 * pinvsq * pinvsq * s.no
+	s.mdot = s.no + 0.5*temp1*rteosq*s.con41 + 0.0625*temp2*rteosq*(13.0-78.0*cosio2+137.0*cosio4)
+	s.argpdot = -0.5*temp1*con42 + 0.0625*temp2*(7.0-114.0*cosio2+395.0*cosio4) +
+		temp3*(3.0-36.0*cosio2+49.0*cosio4)
+	xhdot1 := -temp1 * cosio
+	s.nodedot = xhdot1 + (0.5*temp2*(4.0-19.0*cosio2)+2.0*temp3*(3.0-7.0*cosio2))*cosio
+	xpidot := s.argpdot + s.nodedot
+	s.omgcof = s.bstar * cc3 * math.Cos(s.argpo)
+	if s.ecco > 1.0e-4 {
+		s.xmcof = -x2o3 * coef * s.bstar / eeta
+	}
+	s.nodecf = 3.5 * omeosq * xhdot1 * s.cc1
+	s.t2cof = 1.5 * s.cc1
+	s.xlcof = xlcof(sinio, cosio)
+	s.aycof = -0.5 * j3oj2 * sinio
+	s.delmo = math.Pow(1.0+s.eta*math.Cos(s.mo), 3)
+	s.sinmao = math.Sin(s.mo)
+	s.x7thm1 = 7.0*cosio2 - 1.0
+
+	// deep space initialisation
+	if twoPi/s.no >= 225.0 {
+		s.deep = true
+		s.isimp = true
+		ds := &s.deepTerm
+		c := dscom(epoch, s.ecco, s.argpo, 0, s.inclo, s.nodeo, s.no, ds)
+		s.dsinit(c, xpidot, eccsq)
+	}
+
+	if !s.isimp {
+		cc1sq := s.cc1 * s.cc1
+		s.d2 = 4.0 * ao * tsi * cc1sq
+		temp := s.d2 * tsi * s.cc1 / 3.0
+		s.d3 = (17.0*ao + sfour) * temp
+		s.d4 = 0.5 * temp * ao * tsi * (221.0*ao + 31.0*sfour) * s.cc1
+		s.t3cof = s.d2 + 2.0*cc1sq
+		s.t4cof = 0.25 * (3.0*s.d3 + s.cc1*(12.0*s.d2+10.0*cc1sq))
+		s.t5cof = 0.2 * (3.0*s.d4 + 12.0*s.cc1*s.d3 + 6.0*s.d2*s.d2 + 15.0*cc1sq*(2.0*s.d2+cc1sq))
+	}
+
+	_, _, err := s.propagate(0)
+	return err
+}
+
+// The coefficient of the long period periodics, guarded against a division by zero at an
+// inclination of 180 degrees
+func xlcof(sinio, cosio float64) float64 {
+	denominator := 1.0 + cosio
+	if math.Abs(denominator) <= 1.5e-12 {
+		denominator = 1.5e-12
+	}
+	return -0.25 * j3oj2 * sinio * (3.0 + 5.0*cosio) / denominator
+}
+
+// Propagate calculates the position and velocity of the satellite at a time.
+// Returns:
+//
+//	The position in km and the velocity in km/s in the TEME frame, the true equator and
+//	mean equinox of date, or an error if the model breaks down, e.g. ErrDecayed.
+func (s *Satellite) Propagate(t time.Time) ([3]float64, [3]float64, error) {
+	return s.propagate((julian_day(t) - s.jdEpoch) * 1440)
+}
+
+// Propagate the model, see sgp4 of Vallado et al.
+// Args:
+//
+//	tsince: The time since the epoch in minutes
+func (s *Satellite) propagate(tsince float64) ([3]float64, [3]float64, error) {
+	var r, v [3]float64
+	vkmpersec := earthRadius * xke / 60.0
+	t := tsince
+
+	// secular gravity and atmospheric drag
+	xmdf := s.mo + s.mdot*t
+	argpdf := s.argpo + s.argpdot*t
+	nodedf := s.nodeo + s.nodedot*t
+	argpm := argpdf
+	mm := xmdf
+	t2 := t * t
+	nodem := nodedf + s.nodecf*t2
+	tempa := 1.0 - s.cc1*t
+	tempe := s.bstar * s.cc4 * t
+	templ := s.t2cof * t2
+
+	if !s.isimp {
+		delomg := s.omgcof * t
+		delmtemp := 1.0 + s.eta*math.Cos(xmdf)
+		delm := s.xmcof * (delmtemp*delmtemp*delmtemp - s.delmo)
+		temp := delomg + delm
+		mm = xmdf + temp
+		argpm = argpdf - temp
+		t3 := t2 * t
+		t4 := t3 * t
+		tempa = tempa - s.d2*t2 - s.d3*t3 - s.d4*t4
+		tempe = tempe + s.bstar*s.cc5*(math.Sin(mm)-s.sinmao)
+		templ = templ + s.t3cof*t3 + t4*(s.t4cof+t*s.t5cof)
+	}
+
+	nm := s.no
+	em := s.ecco
+	inclm := s.inclo
+	if s.deep {
+		em, argpm, inclm, mm, nodem, nm = s.dspace(t, em, argpm, inclm, mm, nodem)
+	}
+
+	if nm <= 0.0 {
+		return r, v, ErrMeanMotion
+	}
+	am := math.Pow(xke/nm, x2o3) * tempa * tempa
+	nm = xke / math.Pow(am, 1.5)
+	em = em - tempe
+
+	if em >= 1.0 || em < -0.001 {
+		return r, v, ErrEccentricity
+	}
+	if em < 1.0e-6 {
+		em = 1.0e-6
+	}
+	mm = mm + s.no*templ
+	xlm := mm + argpm + nodem
+
+	nodem = math.Mod(nodem, twoPi)
+	argpm = math.Mod(argpm, twoPi)
+	xlm = math.Mod(xlm, twoPi)
+	mm = math.Mod(xlm-argpm-nodem, twoPi)
+
+	// lunar-solar periodics
+	ep := em
+	xincp := inclm
+	argpp := argpm
+	nodep := nodem
+	mp := mm
+	sinip := math.Sin(inclm)
+	cosip := math.Cos(inclm)
+	aycof, xlcofp := s.aycof, s.xlcof
+	if s.deep {
+		ep, xincp, nodep, argpp, mp = s.deepTerm.dpper(t, ep, xincp, nodep, argpp, mp)
+		if xincp < 0.0 {
+			xincp = -xincp
+			nodep = nodep + math.Pi
+			argpp = argpp - math.Pi
+		}
+		if ep < 0.0 || ep > 1.0 {
+			return r, v, ErrPerturbed
+		}
+
+		// long period periodics
+		sinip = math.Sin(xincp)
+		cosip = math.Cos(xincp)
+		aycof = -0.5 * j3oj2 * sinip
+		xlcofp = xlcof(sinip, cosip)
+	}
+
+	axnl := ep * math.Cos(argpp)
+	temp := 1.0 / (am * (1.0 - ep*ep))
+	aynl := ep*math.Sin(argpp) + temp*aycof
+	xl := mp + argpp + nodep + temp*xlcofp*axnl
+
+	// solve Kepler's equation
+	u := math.Mod(xl-nodep, twoPi)
+	eo1 := u
+	tem5 := 9999.9
+	var sineo1, coseo1 float64
+	for ktr := 1; math.Abs(tem5) >= 1.0e-12 && ktr <= 10; ktr++ {
+		sineo1, coseo1 = math.Sincos(eo1)
+		tem5 = 1.0 - coseo1*axnl - sineo1*aynl
+		tem5 = (u - aynl*coseo1 + axnl*sineo1 - eo1) / tem5
+		if math.Abs(tem5) >= 0.95 {
+			tem5 = math.Copysign(0.95, tem5)
+		}
+		eo1 = eo1 + tem5
+	}
+
+	// short period preliminary quantities
+	ecose := axnl*coseo1 + aynl*sineo1
+	esine := axnl*sineo1 - aynl*coseo1
+	el2 := axnl*axnl + aynl*aynl
+	pl := am * (1.0 - el2)
+	if pl < 0.0 {
+		return r, v, ErrSemiLatus
+	}
+	rl := am * (1.0 - ecose)
+	rdotl := math.Sqrt(am) * esine / rl
+	rvdotl := math.Sqrt(pl) / rl
+	betal := math.Sqrt(1.0 - el2)
+	temp = esine / (1.0 + betal)
+	sinu := am / rl * (sineo1 - aynl - axnl*temp)
+	cosu := am / rl * (coseo1 - axnl + aynl*temp)
+	su := math.Atan2(sinu, cosu)
+	sin2u := (cosu + cosu) * sinu
+	cos2u := 1.0 - 2.0*sinu*sinu
+	temp = 1.0 / pl
+	temp1 := 0.5 * j2 * temp
+	temp2 := temp1 * temp
+
+	// short period periodics
+	con41, x1mth2, x7thm1 := s.con41, s.x1mth2, s.x7thm1
+	if s.deep {
+		cosisq := cosip * cosip
+		con41 = 3.0*cosisq - 1.0
+		x1mth2 = 1.0 - cosisq
+		x7thm1 = 7.0*cosisq - 1.0
+	}
+	mrt := rl*(1.0-1.5*temp2*betal*con41) + 0.5*temp1*x1mth2*cos2u
+	su = su - 0.25*temp2*x7thm1*sin2u
+	xnode := nodep + 1.5*temp2*cosip*sin2u
+	xinc := xincp + 1.5*temp2*cosip*sinip*cos2u
+	mvt := rdotl - nm*temp1*x1mth2*sin2u/xke
+	rvdot := rvdotl + nm*temp1*(x1mth2*cos2u+1.5*con41)/xke
+
+	// orientation vectors
+	sinsu, cossu := math.Sincos(su)
+	snod, cnod := math.Sincos(xnode)
+	sini, cosi := math.Sincos(xinc)
+	xmx := -snod * cosi
+	xmy := cnod * cosi
+	ux := xmx*sinsu + cnod*cossu
+	uy := xmy*sinsu + snod*cossu
+	uz := sini * sinsu
+	vx := xmx*cossu - cnod*sinsu
+	vy := xmy*cossu - snod*sinsu
+	vz := sini * cossu
+
+	r = [3]float64{mrt * ux * earthRadius, mrt * uy * earthRadius, mrt * uz * earthRadius}
+	v = [3]float64{
+		(mvt*ux + rvdot*vx) * vkmpersec,
+		(mvt*uy + rvdot*vy) * vkmpersec,
+		(mvt*uz + rvdot*vz) * vkmpersec,
+	}
+	if mrt < 1.0 {
+		return r, v, ErrDecayed
+	}
+	return r, v, nil
+}
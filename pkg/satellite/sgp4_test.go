@@ -0,0 +1,73 @@
+package satellite
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestPropagate(t *testing.T) {
+	// test vectors of Vallado et al., Revisiting Spacetrack Report #3, AIAA 2006-6753
+	var tests = []struct {
+		lines   string
+		minutes float64
+		r, v    [3]float64
+	}{
+		{vanguard1, 0, [3]float64{7022.46529266, -1400.08296755, 0.03995155}, [3]float64{1.893841015, 6.405893759, 4.534807250}},
+		{vanguard1, 360, [3]float64{-7154.03120202, -3783.17682504, -3536.19412294}, [3]float64{4.741887409, -4.151817765, -2.093935425}},
+		{vanguard1, 720, [3]float64{-7134.59340119, 6531.68641334, 3260.27186483}, [3]float64{-4.113793027, -2.911922039, -2.557327851}},
+		{leo, 0, [3]float64{3988.31022699, 5498.96657235, 0.90055879}, [3]float64{-3.290032738, 2.357652820, 6.496623475}},
+		{leo, 360, [3]float64{4993.62642836, 2890.54969900, -3600.40145627}, [3]float64{0.347333429, 5.707031557, 5.070699638}},
+		{molniya, 0, [3]float64{2349.89483350, -14785.93811562, 0.02119378}, [3]float64{2.721488096, -3.256811655, 4.498416672}},
+		{molniya, 720, [3]float64{2622.13222207, -15125.15464924, 474.51048398}, [3]float64{2.688287199, -3.078426664, 4.494979530}},
+	}
+
+	for _, test := range tests {
+		tle := parse(t, "", test.lines)
+		s, err := New(tle)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, v, err := s.Propagate(tle.Epoch.Add(time.Duration(test.minutes * float64(time.Minute))))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 3; i++ {
+			almostEqualFloat(t, r[i], test.r[i], 1e-4)
+			almostEqualFloat(t, v[i], test.v[i], 1e-7)
+		}
+	}
+}
+
+func TestPropagateGeostationary(t *testing.T) {
+	// a circular equatorial orbit of one sidereal day keeps its radius
+	s, err := New(TLE{Epoch: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), MeanMotion: 1.00273791})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !s.deep {
+		t.Fatal("expected the deep space model")
+	}
+	for hours := 0; hours <= 48; hours += 6 {
+		r, v, err := s.Propagate(s.TLE.Epoch.Add(time.Duration(hours) * time.Hour))
+		if err != nil {
+			t.Fatal(err)
+		}
+		almostEqualFloat(t, math.Sqrt(r[0]*r[0]+r[1]*r[1]+r[2]*r[2]), 42164, 10)
+		almostEqualFloat(t, math.Sqrt(v[0]*v[0]+v[1]*v[1]+v[2]*v[2]), 3.075, 0.005)
+	}
+}
+
+func TestPropagateDecayed(t *testing.T) {
+	// a low orbit with a lot of drag falls down within weeks
+	s, err := New(TLE{Epoch: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), BStar: 0.01, MeanMotion: 16.2, Inclination: 51.6})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the drag drives the mean elements out of range before the satellite reaches the ground
+	_, _, err = s.Propagate(s.TLE.Epoch.Add(60 * 24 * time.Hour))
+	if !errors.Is(err, ErrEccentricity) && !errors.Is(err, ErrDecayed) {
+		t.Fatalf("got %v", err)
+	}
+}
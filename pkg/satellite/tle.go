@@ -0,0 +1,203 @@
+// Package satellite propagates artificial satellites from two-line element sets with
+// the SGP4/SDP4 models and predicts their passes over an observer.
+package satellite
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidTLE = errors.New("invalid two-line element set")
+
+// TLE is a two-line element set as published by NORAD and CelesTrak.
+type TLE struct {
+	Name          string
+	Line1         string
+	Line2         string
+	CatalogNumber int
+	// Epoch is the time at which the elements are valid
+	Epoch time.Time
+	// MeanMotionDot is the first derivative of the mean motion divided by two in revolutions
+	// per day squared, MeanMotionDDot the second derivative divided by six in revolutions
+	// per day cubed
+	MeanMotionDot  float64
+	MeanMotionDDot float64
+	// BStar is the drag term in inverse earth radii
+	BStar float64
+	// Inclination, RightAscension (of the ascending node), ArgumentOfPerigee and MeanAnomaly
+	// are in degrees
+	Inclination       float64
+	RightAscension    float64
+	Eccentricity      float64
+	ArgumentOfPerigee float64
+	MeanAnomaly       float64
+	// MeanMotion is in revolutions per day
+	MeanMotion float64
+}
+
+// Calculate the checksum of a line, the sum of its digits plus one for each minus sign modulo 10
+func checksum(line string) int {
+	sum := 0
+	for _, c := range line[:68] {
+		switch {
+		case c >= '0' && c <= '9':
+			sum += int(c - '0')
+		case c == '-':
+			sum++
+		}
+	}
+	return sum % 10
+}
+
+// Parse a number with an implied decimal point and exponent, e.g. " 12345-3" for 0.12345e-3
+func parse_exponential(field string) (float64, error) {
+	field = strings.TrimSpace(field)
+	if field == "" {
+		return 0, nil
+	}
+	sign := 1.0
+	if field[0] == '-' || field[0] == '+' {
+		if field[0] == '-' {
+			sign = -1
+		}
+		field = field[1:]
+	}
+	if len(field) < 3 {
+		return 0, fmt.Errorf("invalid number %q", field)
+	}
+	mantissa, err := strconv.ParseFloat("0."+field[:len(field)-2], 64)
+	if err != nil {
+		return 0, err
+	}
+	exponent, err := strconv.Atoi(field[len(field)-2:])
+	if err != nil {
+		return 0, err
+	}
+	return sign * mantissa * math.Pow10(exponent), nil
+}
+
+// Parse a decimal field of a line, ignoring the surrounding spaces
+func parse_field(line string, from, to int) (float64, error) {
+	return strconv.ParseFloat(strings.TrimSpace(line[from:to]), 64)
+}
+
+// ParseTLE parses a two-line element set.
+// Args:
+//
+//	name:         The name of the satellite, e.g. the title line of a three-line set
+//	line1, line2: The two lines of elements
+//
+// Returns:
+//
+//	The elements, or an error wrapping ErrInvalidTLE if a line is malformed or its
+//	checksum does not match.
+func ParseTLE(name, line1, line2 string) (TLE, error) {
+	line1 = strings.TrimRight(line1, " \r\n")
+	line2 = strings.TrimRight(line2, " \r\n")
+	if len(line1) < 68 || len(line2) < 68 || line1[0] != '1' || line2[0] != '2' {
+		return TLE{}, fmt.Errorf("%w: lines must start with 1 and 2 and have 69 columns", ErrInvalidTLE)
+	}
+	for i, line := range []string{line1, line2} {
+		if len(line) >= 69 && line[68] != ' ' && int(line[68]-'0') != checksum(line) {
+			return TLE{}, fmt.Errorf("%w: checksum of line %d is %d, not %c", ErrInvalidTLE, i+1, checksum(line), line[68])
+		}
+	}
+
+	tle := TLE{Name: strings.TrimSpace(name), Line1: line1, Line2: line2}
+	var errs [12]error
+	tle.CatalogNumber, errs[0] = strconv.Atoi(strings.TrimSpace(line1[2:7]))
+
+	year, err := strconv.Atoi(strings.TrimSpace(line1[18:20]))
+	errs[1] = err
+	// two digit years from 57 refer to the 1900s, Sputnik was launched in 1957
+	if year < 57 {
+		year += 2000
+	} else {
+		year += 1900
+	}
+	day, err := parse_field(line1, 20, 32)
+	errs[2] = err
+	tle.Epoch = time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration((day - 1) * 24 * float64(time.Hour)))
+
+	tle.MeanMotionDot, errs[3] = parse_field(line1, 33, 43)
+	tle.MeanMotionDDot, errs[4] = parse_exponential(line1[44:52])
+	tle.BStar, errs[5] = parse_exponential(line1[53:61])
+
+	tle.Inclination, errs[6] = parse_field(line2, 8, 16)
+	tle.RightAscension, errs[7] = parse_field(line2, 17, 25)
+	tle.Eccentricity, errs[8] = strconv.ParseFloat("0."+strings.TrimSpace(line2[26:33]), 64)
+	tle.ArgumentOfPerigee, errs[9] = parse_field(line2, 34, 42)
+	tle.MeanAnomaly, errs[10] = parse_field(line2, 43, 51)
+	tle.MeanMotion, errs[11] = parse_field(line2, 52, 63)
+	if err := errors.Join(errs[:]...); err != nil {
+		return TLE{}, fmt.Errorf("%w: %v", ErrInvalidTLE, err)
+	}
+	return tle, nil
+}
+
+// ReadTLE reads the element sets of a file in the two-line or three-line format, where
+// a title line with the name of the satellite precedes the two lines of elements.
+func ReadTLE(r io.Reader) ([]TLE, error) {
+	var (
+		tles  []TLE
+		name  string
+		line1 string
+	)
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimRight(scanner.Text(), " \r")
+		switch {
+		case strings.TrimSpace(line) == "":
+			continue
+		case strings.HasPrefix(line, "1 ") && line1 == "":
+			line1 = line
+		case strings.HasPrefix(line, "2 ") && line1 != "":
+			tle, err := ParseTLE(name, line1, line)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+			}
+			tles = append(tles, tle)
+			name, line1 = "", ""
+		case line1 == "":
+			name = strings.TrimPrefix(line, "0 ")
+		default:
+			return nil, fmt.Errorf("line %d: %w: second line of elements missing", lineNumber, ErrInvalidTLE)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if line1 != "" {
+		return nil, fmt.Errorf("%w: second line of elements missing at the end", ErrInvalidTLE)
+	}
+	return tles, nil
+}
+
+// LoadTLEFile reads the element sets of a local file, see ReadTLE.
+func LoadTLEFile(path string) ([]TLE, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadTLE(f)
+}
+
+// FindTLE looks up an element set by the name of the satellite, ignoring case, or by its
+// catalogue number.
+func FindTLE(tles []TLE, nameOrNumber string) (TLE, bool) {
+	number, err := strconv.Atoi(nameOrNumber)
+	for _, tle := range tles {
+		if strings.EqualFold(tle.Name, nameOrNumber) || (err == nil && tle.CatalogNumber == number) {
+			return tle, true
+		}
+	}
+	return TLE{}, false
+}
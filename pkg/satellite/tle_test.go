@@ -0,0 +1,135 @@
+package satellite
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func almostEqualFloat(t *testing.T, f1, f2, allowedDiff float64) {
+	t.Helper()
+	if abs := math.Abs(f1 - f2); abs > allowedDiff {
+		t.Fatalf("diff: %f, f1 %f, f2 %f\n", abs, f1, f2)
+	}
+}
+
+func almostEqualTime(t *testing.T, t1, t2 time.Time, allowedDiff time.Duration) {
+	t.Helper()
+	if d := t1.Sub(t2).Abs(); d > allowedDiff {
+		t.Fatalf("diff: %q, t1 %q, t2 %q\n", d, t1, t2)
+	}
+}
+
+const (
+	vanguard1 = "1 00005U 58002B   00179.78495062  .00000023  00000-0  28098-4 0  4753\n" +
+		"2 00005  34.2682 348.7242 1859667 331.7664  19.3264 10.82419157413667"
+	molniya = "1 08195U 75081A   06176.33215444  .00000099  00000-0  11873-3 0   813\n" +
+		"2 08195  64.1586 279.0717 6877146 264.7651  20.2257  2.00491383225656"
+	leo = "1 06251U 62025E   06176.82412014  .00008885  00000-0  12808-3 0  3985\n" +
+		"2 06251  58.0579  54.0425 0030035 139.1568 221.1854 15.56387291  6774"
+)
+
+func parse(t *testing.T, name, lines string) TLE {
+	t.Helper()
+	line1, line2, _ := strings.Cut(lines, "\n")
+	tle, err := ParseTLE(name, line1, line2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tle
+}
+
+func TestParseTLE(t *testing.T) {
+	tle := parse(t, "VANGUARD 1", vanguard1)
+	if tle.Name != "VANGUARD 1" || tle.CatalogNumber != 5 {
+		t.Fatalf("name %q, number %d", tle.Name, tle.CatalogNumber)
+	}
+	almostEqualTime(t, tle.Epoch, time.Date(2000, 6, 27, 18, 50, 19, 733568000, time.UTC), time.Millisecond)
+	almostEqualFloat(t, tle.MeanMotionDot, 0.00000023, 1e-12)
+	almostEqualFloat(t, tle.BStar, 0.28098e-4, 1e-12)
+	almostEqualFloat(t, tle.Inclination, 34.2682, 1e-9)
+	almostEqualFloat(t, tle.RightAscension, 348.7242, 1e-9)
+	almostEqualFloat(t, tle.Eccentricity, 0.1859667, 1e-9)
+	almostEqualFloat(t, tle.ArgumentOfPerigee, 331.7664, 1e-9)
+	almostEqualFloat(t, tle.MeanAnomaly, 19.3264, 1e-9)
+	almostEqualFloat(t, tle.MeanMotion, 10.82419157, 1e-9)
+
+	// the epoch of the 1900s
+	tle = parse(t, "", "1 00005U 58002B   99179.78495062  .00000023  00000-0  28098-4 0  4751\n"+
+		"2 00005  34.2682 348.7242 1859667 331.7664  19.3264 10.82419157413667")
+	if tle.Epoch.Year() != 1999 {
+		t.Fatalf("year %d", tle.Epoch.Year())
+	}
+}
+
+func TestParseExponential(t *testing.T) {
+	var tests = []struct {
+		field string
+		want  float64
+	}{
+		{" 28098-4", 0.28098e-4},
+		{"-11606-4", -0.11606e-4},
+		{" 00000-0", 0},
+		{" 12345+1", 1.2345},
+		{"        ", 0},
+	}
+	for _, test := range tests {
+		got, err := parse_exponential(test.field)
+		if err != nil {
+			t.Fatal(err)
+		}
+		almostEqualFloat(t, got, test.want, 1e-15)
+	}
+}
+
+func TestParseTLEInvalid(t *testing.T) {
+	line1, line2, _ := strings.Cut(vanguard1, "\n")
+	var tests = []struct {
+		name, line1, line2 string
+	}{
+		{"checksum", line1[:68] + "0", line2},
+		{"short", line1[:40], line2},
+		{"swapped", line2, line1},
+		{"number", line1, line2[:8] + "34.2x82" + line2[15:]},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := ParseTLE("", test.line1, test.line2); !errors.Is(err, ErrInvalidTLE) {
+				t.Fatalf("got %v", err)
+			}
+		})
+	}
+}
+
+func TestReadTLE(t *testing.T) {
+	input := "VANGUARD 1\n" + vanguard1 + "\n\n" + molniya + "\r\n0 SL-3 R/B\n" + leo + "\n"
+	tles, err := ReadTLE(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tles) != 3 {
+		t.Fatalf("got %d element sets", len(tles))
+	}
+	var names = []string{"VANGUARD 1", "", "SL-3 R/B"}
+	for i, name := range names {
+		if tles[i].Name != name {
+			t.Fatalf("name %q, want %q", tles[i].Name, name)
+		}
+	}
+
+	if tle, ok := FindTLE(tles, "vanguard 1"); !ok || tle.CatalogNumber != 5 {
+		t.Fatalf("find by name %v", ok)
+	}
+	if tle, ok := FindTLE(tles, "8195"); !ok || tle.CatalogNumber != 8195 {
+		t.Fatalf("find by number %v", ok)
+	}
+	if _, ok := FindTLE(tles, "ISS"); ok {
+		t.Fatal("found ISS")
+	}
+
+	if _, err := ReadTLE(strings.NewReader("VANGUARD 1\n" + vanguard1[:69])); !errors.Is(err, ErrInvalidTLE) {
+		t.Fatalf("got %v", err)
+	}
+}